@@ -1,14 +1,23 @@
 package app
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 )
 
@@ -54,18 +63,106 @@ func TestSimAppExportAndBlockedAddrs(t *testing.T) {
 	app.Commit()
 
 	// Making a new app object with the db, so that initchain hasn't been called
-	// app2 := NewJeongseupApp(
-	// 	log.NewTMLogger(log.NewSyncWriter(os.Stdout)),
-	// 	db,
-	// 	nil,
-	// 	true,
-	// 	map[int64]bool{},
-	// 	DefaultNodeHome,
-	// 	0,
-	// 	encCfg,
-	// 	helpers.EmptyAppOptions{},
-	// )
-
-	// _, err = app2.ExportAppStateAndValidators(false, []string{})
-	// require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
+	app2 := NewJeongseupApp(
+		log.NewTMLogger(log.NewSyncWriter(os.Stdout)),
+		db,
+		nil,
+		true,
+		map[int64]bool{},
+		DefaultNodeHome,
+		0,
+		encCfg,
+		helpers.EmptyAppOptions{},
+	)
+
+	_, err = app2.ExportAppStateAndValidators(false, []string{})
+	require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
+
+	_, err = app2.ExportAppStateAndValidators(true, []string{})
+	require.NoError(t, err, "zero height ExportAppStateAndValidators should not have an error")
+}
+
+func TestVestingAccountExportImport(t *testing.T) {
+	encCfg := MakeEncodingConfig()
+	app := NewJeongseupApp(
+		log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, DefaultNodeHome, 0, encCfg, helpers.EmptyAppOptions{},
+	)
+
+	// genesis에 continuous vesting account 하나 넣기
+	_, _, addr := testdata.KeyTestPubAddr()
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000))
+	startTime := time.Now().Unix()
+	vestingAcc := vestingtypes.NewContinuousVestingAccount(
+		authtypes.NewBaseAccountWithAddress(addr), coins, startTime, startTime+3600,
+	)
+
+	genesisState := NewDefaultGenesisState(encCfg.Marshaler)
+	authGenState := authtypes.NewGenesisState(authtypes.DefaultParams(), authtypes.GenesisAccounts{vestingAcc})
+	genesisState[authtypes.ModuleName] = encCfg.Marshaler.MustMarshalJSON(authGenState)
+	bankGenState := banktypes.NewGenesisState(
+		banktypes.DefaultParams(), []banktypes.Balance{{Address: addr.String(), Coins: coins}}, coins, []banktypes.Metadata{},
+	)
+	genesisState[banktypes.ModuleName] = encCfg.Marshaler.MustMarshalJSON(bankGenState)
+
+	stateBytes, err := json.MarshalIndent(genesisState, "", "  ")
+	require.NoError(t, err)
+
+	app.InitChain(abci.RequestInitChain{Validators: []abci.ValidatorUpdate{}, AppStateBytes: stateBytes})
+	app.Commit()
+
+	exported, err := app.ExportAppStateAndValidators(false, []string{})
+	require.NoError(t, err)
+
+	// export된 genesis로 새 app을 띄워도 vesting account가 그대로 있어야 함
+	newApp := NewJeongseupApp(
+		log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, DefaultNodeHome, 0, encCfg, helpers.EmptyAppOptions{},
+	)
+	newApp.InitChain(abci.RequestInitChain{Validators: []abci.ValidatorUpdate{}, AppStateBytes: exported.AppState})
+	newApp.Commit()
+
+	ctx := newApp.NewContext(true, tmproto.Header{Height: newApp.LastBlockHeight()})
+	acc, ok := newApp.AccountKeeper.GetAccount(ctx, addr).(*vestingtypes.ContinuousVestingAccount)
+	require.True(t, ok, "expected continuous vesting account after import")
+	require.Equal(t, coins, acc.GetOriginalVesting())
+	require.Equal(t, startTime, acc.GetStartTime())
+	require.Equal(t, startTime+3600, acc.GetEndTime())
+}
+
+func TestExportAppStateTo(t *testing.T) {
+	encCfg := MakeEncodingConfig()
+	app := NewJeongseupApp(
+		log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{},
+		DefaultNodeHome, 0, encCfg, helpers.EmptyAppOptions{},
+	)
+
+	stateBytes, err := json.MarshalIndent(NewDefaultGenesisState(encCfg.Marshaler), "", "  ")
+	require.NoError(t, err)
+
+	app.InitChain(abci.RequestInitChain{
+		Validators:    []abci.ValidatorUpdate{},
+		AppStateBytes: stateBytes,
+	})
+	app.Commit()
+
+	// streamed export must match the in-memory MarshalIndent of all modules
+	ctx := app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight()})
+	expected, err := json.MarshalIndent(app.mm.ExportGenesis(ctx, app.appCodec), "", "  ")
+	require.NoError(t, err)
+
+	exported, err := app.ExportAppStateAndValidators(false, []string{})
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(exported.AppState))
+
+	var compact bytes.Buffer
+	_, err = app.ExportAppStateTo(&compact, ExportOptions{Modules: []string{stakingtypes.ModuleName, banktypes.ModuleName}})
+	require.NoError(t, err)
+	require.NotContains(t, compact.String(), "\n")
+
+	var appState map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(compact.Bytes(), &appState))
+	require.Len(t, appState, 2)
+	require.JSONEq(t, string(app.mm.Modules[banktypes.ModuleName].ExportGenesis(ctx, app.appCodec)), string(appState[banktypes.ModuleName]))
+
+	_, err = app.ExportAppStateTo(&compact, ExportOptions{Modules: []string{"unknown"}})
+	require.Error(t, err)
 }
@@ -0,0 +1,252 @@
+package apptesting
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// export 한 genesis 로 새 app 을 InitChain 했을 때 모든 KV store 가 같은지 비교하는
+// helper 들. genesis restart 로 하는 upgrade 가 state 를 잃지 않는지 확인하는 용도.
+
+// StoreSkipPrefixes maps a store name (the key of app.GetKeys()) to the key
+// prefixes which are not compared after an export/import round trip.
+type StoreSkipPrefixes map[string][][]byte
+
+// DefaultStoreSkipPrefixes returns the prefixes which legitimately differ after
+// an export/import round trip.
+func DefaultStoreSkipPrefixes() StoreSkipPrefixes {
+	return StoreSkipPrefixes{
+		stakingtypes.StoreKey: {
+			// queue 는 InitGenesis 에서 다시 쌓이므로 같은 시간의 entry 순서가 바뀔 수 있고,
+			// historical info 는 export 되지 않는다.
+			stakingtypes.UnbondingQueueKey, stakingtypes.RedelegationQueueKey, stakingtypes.ValidatorQueueKey,
+			stakingtypes.HistoricalInfoKey,
+		},
+	}
+}
+
+// StoreDiff is the first mismatching key of a store. A or B has an empty key
+// if the key only exists in the other store.
+type StoreDiff struct {
+	Store   string
+	A, B    kv.Pair
+	Decoded string
+}
+
+func (d StoreDiff) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "store %s: first mismatch\n", d.Store)
+	fmt.Fprintf(&sb, "  exported: key %X value %X\n", d.A.Key, d.A.Value)
+	fmt.Fprintf(&sb, "  imported: key %X value %X\n", d.B.Key, d.B.Value)
+	if d.Decoded != "" {
+		fmt.Fprintf(&sb, "  decoded:\n%s", d.Decoded)
+	}
+
+	return sb.String()
+}
+
+// ExportAndImport exports app at its last committed height and calls InitChain
+// on a fresh app with the exported genesis and validators. The imported app is
+// returned with the genesis state not committed yet.
+func ExportAndImport(t *testing.T, app *jscapp.JeongseupApp, forZeroHeight bool, jailAllowedAddrs []string) *jscapp.JeongseupApp {
+	exported, err := app.ExportAppStateAndValidators(forZeroHeight, jailAllowedAddrs)
+	require.NoError(t, err)
+
+	validators := make([]abci.ValidatorUpdate, len(exported.Validators))
+	for i, v := range exported.Validators {
+		validators[i] = tmtypes.TM2PB.ValidatorUpdate(tmtypes.NewValidator(v.PubKey, v.Power))
+	}
+
+	newApp := setup(5)
+
+	// InitChain panics if the validator set from InitGenesis differs from the exported one.
+	res := newApp.InitChain(abci.RequestInitChain{
+		ChainId:         helpers.TestChainID,
+		InitialHeight:   exported.Height,
+		ConsensusParams: exported.ConsensusParams,
+		Validators:      validators,
+		AppStateBytes:   exported.AppState,
+	})
+	require.Equal(t, validators, res.Validators)
+
+	return newApp
+}
+
+// DiffAppStores compares every store of app.GetKeys() between the committed state of
+// app and the InitChain state of newApp, skipping the given prefixes. It returns
+// the first mismatch of each differing store, sorted by store name.
+func DiffAppStores(app, newApp *jscapp.JeongseupApp, skip StoreSkipPrefixes) []StoreDiff {
+	ctxA := app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight()})
+	ctxB := newApp.NewContext(false, tmproto.Header{})
+
+	keys, newKeys := app.GetKeys(), newApp.GetKeys()
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diffs []StoreDiff
+	for _, name := range names {
+		kvA, kvB, found := firstStoreMismatch(ctxA.KVStore(keys[name]), ctxB.KVStore(newKeys[name]), skip[name])
+		if !found {
+			continue
+		}
+
+		diffs = append(diffs, StoreDiff{
+			Store:   name,
+			A:       kvA,
+			B:       kvB,
+			Decoded: decodeStorePair(app.SimulationManager().StoreDecoders[name], kvA, kvB),
+		})
+	}
+
+	return diffs
+}
+
+// RequireExportImportEqual exports app, imports it into a fresh app and fails
+// the test with the first mismatching key of every differing store.
+func RequireExportImportEqual(t *testing.T, app *jscapp.JeongseupApp, skip StoreSkipPrefixes) *jscapp.JeongseupApp {
+	newApp := ExportAndImport(t, app, false, []string{})
+
+	diffs := DiffAppStores(app, newApp, skip)
+	if len(diffs) > 0 {
+		msgs := make([]string, len(diffs))
+		for i, d := range diffs {
+			msgs[i] = d.String()
+		}
+		require.FailNow(t, "stores differ after export/import", strings.Join(msgs, "\n"))
+	}
+
+	return newApp
+}
+
+// SnapshotStores copies every store of app as an export sees it, i.e. the
+// check state on top of the last commit.
+func SnapshotStores(app *jscapp.JeongseupApp) map[string]sdk.KVStore {
+	ctx := app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight()})
+
+	keys := app.GetKeys()
+	snapshot := make(map[string]sdk.KVStore, len(keys))
+	for name, key := range keys {
+		store := dbadapter.Store{DB: dbm.NewMemDB()}
+		iter := ctx.KVStore(key).Iterator(nil, nil)
+		for ; iter.Valid(); iter.Next() {
+			store.Set(iter.Key(), iter.Value())
+		}
+		iter.Close()
+		snapshot[name] = store
+	}
+
+	return snapshot
+}
+
+// RequireStoresEqual fails if a store of app differs from the snapshot.
+func RequireStoresEqual(t *testing.T, snapshot map[string]sdk.KVStore, app *jscapp.JeongseupApp) {
+	ctx := app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight()})
+
+	for name, key := range app.GetKeys() {
+		kvA, kvB, found := firstStoreMismatch(snapshot[name], ctx.KVStore(key), nil)
+		require.False(t, found, "store %s changed: key %X value %X -> key %X value %X",
+			name, kvA.Key, kvA.Value, kvB.Key, kvB.Value)
+	}
+}
+
+// firstStoreMismatch walks both stores in key order. Unlike sdk.DiffKVStores a
+// key missing on one side does not shift the comparison of the next keys.
+func firstStoreMismatch(a, b sdk.KVStore, skip [][]byte) (kv.Pair, kv.Pair, bool) {
+	iterA := a.Iterator(nil, nil)
+	defer iterA.Close()
+	iterB := b.Iterator(nil, nil)
+	defer iterB.Close()
+
+	for {
+		skipKeys(iterA, skip)
+		skipKeys(iterB, skip)
+
+		if !iterA.Valid() && !iterB.Valid() {
+			return kv.Pair{}, kv.Pair{}, false
+		}
+
+		var kvA, kvB kv.Pair
+		switch {
+		case !iterB.Valid():
+			kvA = kv.Pair{Key: iterA.Key(), Value: iterA.Value()}
+		case !iterA.Valid():
+			kvB = kv.Pair{Key: iterB.Key(), Value: iterB.Value()}
+		default:
+			kvA = kv.Pair{Key: iterA.Key(), Value: iterA.Value()}
+			kvB = kv.Pair{Key: iterB.Key(), Value: iterB.Value()}
+			switch cmp := bytes.Compare(kvA.Key, kvB.Key); {
+			case cmp < 0:
+				kvB = kv.Pair{}
+			case cmp > 0:
+				kvA = kv.Pair{}
+			}
+		}
+
+		if !bytes.Equal(kvA.Key, kvB.Key) || !bytes.Equal(kvA.Value, kvB.Value) {
+			return kvA, kvB, true
+		}
+
+		iterA.Next()
+		iterB.Next()
+	}
+}
+
+func skipKeys(iter sdk.Iterator, skip [][]byte) {
+	for ; iter.Valid(); iter.Next() {
+		if !hasAnyPrefix(iter.Key(), skip) {
+			return
+		}
+	}
+}
+
+func hasAnyPrefix(key []byte, prefixes [][]byte) bool {
+	for _, prefix := range prefixes {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeStorePair decodes the pair with the module's simulation store decoder.
+// The decoders panic on keys they do not know, e.g. a key missing on one side.
+func decodeStorePair(decoder func(kvA, kvB kv.Pair) string, kvA, kvB kv.Pair) (decoded string) {
+	if decoder == nil {
+		return ""
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			decoded = ""
+		}
+	}()
+
+	if len(kvA.Key) == 0 {
+		kvA.Key = kvB.Key
+	}
+	if len(kvB.Key) == 0 {
+		kvB.Key = kvA.Key
+	}
+
+	return decoder(kvA, kvB)
+}
@@ -0,0 +1,324 @@
+// Package apptesting runs JeongseupApp with its real wiring for keeper, ante
+// and end-to-end tests. Only tests import it, so testing and testify are not
+// linked into jeongseupd.
+package apptesting
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// ref: https://github.com/cosmos/cosmos-sdk/blob/v0.45.4/simapp/test_helpers.go
+// 테스트에서 NewJeongseupApp + genesis 를 매번 손으로 만들지 않도록 하는 helper 들.
+// Setup 계열 함수는 genesis 를 height 1 로 commit 한 상태의 app 을 돌려주고,
+// 그 뒤 블록은 BeginNextBlock / EndBlockAndCommit / NextBlock 으로 진행한다.
+
+// DefaultConsensusParams defines the default Tendermint consensus params used in
+// JeongseupApp testing.
+var DefaultConsensusParams = &abci.ConsensusParams{
+	Block: &abci.BlockParams{
+		MaxBytes: 200000,
+		MaxGas:   2000000,
+	},
+	Evidence: &tmproto.EvidenceParams{
+		MaxAgeNumBlocks: 302400,
+		MaxAgeDuration:  504 * time.Hour, // 3 weeks is the max duration
+		MaxBytes:        10000,
+	},
+	Validator: &tmproto.ValidatorParams{
+		PubKeyTypes: []string{
+			tmtypes.ABCIPubKeyTypeEd25519,
+		},
+	},
+}
+
+// DefaultBondAmount is the self-bonded amount of each genesis validator
+// created by SetupWithGenesisValSet.
+var DefaultBondAmount = sdk.TokensFromConsensusPower(1, sdk.DefaultPowerReduction)
+
+func setup(invCheckPeriod uint) *jscapp.JeongseupApp {
+	return jscapp.NewJeongseupApp(
+		log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{},
+		jscapp.DefaultNodeHome, invCheckPeriod, jscapp.MakeEncodingConfig(), helpers.EmptyAppOptions{},
+	)
+}
+
+// Setup initializes a new JeongseupApp with a single bonded validator and one
+// funded genesis account. With isCheckTx the app is returned without calling
+// InitChain, for tests which only need the app wiring. A Nop logger is set.
+func Setup(t *testing.T, isCheckTx bool) *jscapp.JeongseupApp {
+	if isCheckTx {
+		return setup(5)
+	}
+
+	valSet := tmtypes.NewValidatorSet([]*tmtypes.Validator{
+		tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1),
+	})
+
+	acc := authtypes.NewBaseAccountWithAddress(sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()))
+	balance := banktypes.Balance{
+		Address: acc.GetAddress().String(),
+		Coins:   sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(100_000_000_000_000))),
+	}
+
+	return SetupWithGenesisValSet(t, valSet, []authtypes.GenesisAccount{acc}, balance)
+}
+
+// SetupWithGenesisValSet initializes a new JeongseupApp with a validator set and
+// genesis accounts that also act as delegators. Each validator is bonded with
+// DefaultBondAmount delegated from the first genesis account. The genesis is
+// committed, so the app is at height 1 without an open block.
+func SetupWithGenesisValSet(t *testing.T, valSet *tmtypes.ValidatorSet, genAccs []authtypes.GenesisAccount, balances ...banktypes.Balance) *jscapp.JeongseupApp {
+	app := setup(5)
+	InitChainWithValSet(t, app, valSet, genAccs, balances...)
+
+	return app
+}
+
+// InitChainWithValSet runs InitChain on app with the genesis state of
+// GenesisStateWithValSet and commits it, e.g. for an app on a db given by the
+// test.
+func InitChainWithValSet(t *testing.T, app *jscapp.JeongseupApp, valSet *tmtypes.ValidatorSet, genAccs []authtypes.GenesisAccount, balances ...banktypes.Balance) {
+	genesisState := GenesisStateWithValSet(t, app, jscapp.NewDefaultGenesisState(app.AppCodec()), valSet, genAccs, balances...)
+
+	stateBytes, err := json.MarshalIndent(genesisState, "", " ")
+	require.NoError(t, err)
+
+	// init chain will set the validator set and initialize the genesis accounts
+	app.InitChain(
+		abci.RequestInitChain{
+			ChainId:         helpers.TestChainID,
+			Validators:      []abci.ValidatorUpdate{},
+			ConsensusParams: DefaultConsensusParams,
+			AppStateBytes:   stateBytes,
+		},
+	)
+
+	// commit genesis changes
+	app.Commit()
+}
+
+// GenesisStateWithValSet returns genesisState with the given genesis accounts,
+// balances and bonded validators. The validators also get slashing signing
+// infos, since the AfterValidatorBonded hook does not run for validators
+// which are already bonded in genesis.
+func GenesisStateWithValSet(
+	t *testing.T, app *jscapp.JeongseupApp, genesisState jscapp.GenesisState,
+	valSet *tmtypes.ValidatorSet, genAccs []authtypes.GenesisAccount, balances ...banktypes.Balance,
+) jscapp.GenesisState {
+	require.NotEmpty(t, genAccs, "the first genesis account is the delegator of the validators")
+
+	// set genesis accounts
+	authGenesis := authtypes.NewGenesisState(authtypes.DefaultParams(), genAccs)
+	genesisState[authtypes.ModuleName] = app.AppCodec().MustMarshalJSON(authGenesis)
+
+	validators := make([]stakingtypes.Validator, 0, len(valSet.Validators))
+	delegations := make([]stakingtypes.Delegation, 0, len(valSet.Validators))
+	signingInfos := make([]slashingtypes.SigningInfo, 0, len(valSet.Validators))
+
+	for _, val := range valSet.Validators {
+		pk, err := cryptocodec.FromTmPubKeyInterface(val.PubKey)
+		require.NoError(t, err)
+		pkAny, err := codectypes.NewAnyWithValue(pk)
+		require.NoError(t, err)
+
+		validator := stakingtypes.Validator{
+			OperatorAddress:   sdk.ValAddress(val.Address).String(),
+			ConsensusPubkey:   pkAny,
+			Jailed:            false,
+			Status:            stakingtypes.Bonded,
+			Tokens:            DefaultBondAmount,
+			DelegatorShares:   DefaultBondAmount.ToDec(),
+			Description:       stakingtypes.Description{},
+			UnbondingHeight:   int64(0),
+			UnbondingTime:     time.Unix(0, 0).UTC(),
+			Commission:        stakingtypes.NewCommission(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()),
+			MinSelfDelegation: sdk.ZeroInt(),
+		}
+		validators = append(validators, validator)
+		delegations = append(delegations, stakingtypes.NewDelegation(genAccs[0].GetAddress(), val.Address.Bytes(), DefaultBondAmount.ToDec()))
+
+		consAddr := sdk.ConsAddress(val.Address)
+		signingInfos = append(signingInfos, slashingtypes.SigningInfo{
+			Address:              consAddr.String(),
+			ValidatorSigningInfo: slashingtypes.NewValidatorSigningInfo(consAddr, 0, 0, time.Unix(0, 0).UTC(), false, 0),
+		})
+	}
+
+	// set validators and delegations
+	stakingGenesis := stakingtypes.NewGenesisState(stakingtypes.DefaultParams(), validators, delegations)
+	genesisState[stakingtypes.ModuleName] = app.AppCodec().MustMarshalJSON(stakingGenesis)
+
+	slashingGenesis := slashingtypes.NewGenesisState(slashingtypes.DefaultParams(), signingInfos, []slashingtypes.ValidatorMissedBlocks{})
+	genesisState[slashingtypes.ModuleName] = app.AppCodec().MustMarshalJSON(slashingGenesis)
+
+	totalSupply := sdk.NewCoins()
+	for _, b := range balances {
+		totalSupply = totalSupply.Add(b.Coins...)
+	}
+
+	// add bonded amount to bonded pool module account
+	bondedCoins := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, DefaultBondAmount.MulRaw(int64(len(validators)))))
+	balances = append(balances, banktypes.Balance{
+		Address: authtypes.NewModuleAddress(stakingtypes.BondedPoolName).String(),
+		Coins:   bondedCoins,
+	})
+	totalSupply = totalSupply.Add(bondedCoins...)
+
+	// update total supply
+	bankGenesis := banktypes.NewGenesisState(banktypes.DefaultGenesisState().Params, balances, totalSupply, []banktypes.Metadata{})
+	genesisState[banktypes.ModuleName] = app.AppCodec().MustMarshalJSON(bankGenesis)
+
+	return genesisState
+}
+
+// NewBlockHeader returns the header of the block following the last committed one.
+func NewBlockHeader(app *jscapp.JeongseupApp, blockTime time.Time) tmproto.Header {
+	return tmproto.Header{
+		ChainID: helpers.TestChainID,
+		Height:  app.LastBlockHeight() + 1,
+		Time:    blockTime,
+		AppHash: app.LastCommitID().Hash,
+	}
+}
+
+// BeginNextBlock begins the block following the last committed one and returns
+// a context on its deliver state, e.g. to call keepers in the block.
+func BeginNextBlock(app *jscapp.JeongseupApp, blockTime time.Time) sdk.Context {
+	header := NewBlockHeader(app, blockTime)
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+	return app.BaseApp.NewContext(false, header)
+}
+
+// EndBlockAndCommit ends the open block and commits it.
+func EndBlockAndCommit(app *jscapp.JeongseupApp) abci.ResponseEndBlock {
+	res := app.EndBlock(abci.RequestEndBlock{Height: app.LastBlockHeight() + 1})
+	app.Commit()
+
+	return res
+}
+
+// NextBlock runs and commits an empty block at blockTime.
+func NextBlock(app *jscapp.JeongseupApp, blockTime time.Time) abci.ResponseEndBlock {
+	BeginNextBlock(app, blockTime)
+	return EndBlockAndCommit(app)
+}
+
+// AdvanceBlocks runs n empty blocks, the first one at startTime and each next
+// one blockInterval later. It returns the time of the last block.
+func AdvanceBlocks(app *jscapp.JeongseupApp, n int, startTime time.Time, blockInterval time.Duration) time.Time {
+	blockTime := startTime
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			blockTime = blockTime.Add(blockInterval)
+		}
+		NextBlock(app, blockTime)
+	}
+
+	return blockTime
+}
+
+// FundAccount mints coins and sends them to addr.
+func FundAccount(app *jscapp.JeongseupApp, ctx sdk.Context, addr sdk.AccAddress, coins sdk.Coins) error {
+	if err := app.BankKeeper.MintCoins(ctx, minttypes.ModuleName, coins); err != nil {
+		return err
+	}
+
+	return app.BankKeeper.SendCoinsFromModuleToAccount(ctx, minttypes.ModuleName, addr, coins)
+}
+
+// AddTestAddrs constructs and returns accNum amount of accounts with an
+// initial balance of accAmt in the bond denom.
+func AddTestAddrs(t *testing.T, app *jscapp.JeongseupApp, ctx sdk.Context, accNum int, accAmt sdk.Int) []sdk.AccAddress {
+	initCoins := sdk.NewCoins(sdk.NewCoin(app.StakingKeeper.BondDenom(ctx), accAmt))
+
+	testAddrs := make([]sdk.AccAddress, accNum)
+	for i := range testAddrs {
+		testAddrs[i] = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+		require.NoError(t, FundAccount(app, ctx, testAddrs[i], initCoins))
+	}
+
+	return testAddrs
+}
+
+// CheckBalance checks the balance of an account.
+func CheckBalance(t *testing.T, app *jscapp.JeongseupApp, addr sdk.AccAddress, balances sdk.Coins) {
+	ctxCheck := app.BaseApp.NewContext(true, tmproto.Header{})
+	require.True(t, balances.IsEqual(app.BankKeeper.GetAllBalances(ctxCheck, addr)))
+}
+
+// SignCheckDeliver checks a generated signed transaction and simulates a
+// block commitment with the given transaction. A test assertion is made using
+// the parameter 'expPass' against the result. A corresponding result is
+// returned.
+func SignCheckDeliver(
+	t *testing.T, txCfg client.TxConfig, app *baseapp.BaseApp, header tmproto.Header, msgs []sdk.Msg,
+	chainID string, accNums, accSeqs []uint64, expSimPass, expPass bool, priv ...cryptotypes.PrivKey,
+) (sdk.GasInfo, *sdk.Result, error) {
+	tx, err := helpers.GenTx(
+		txCfg,
+		msgs,
+		sdk.NewCoins(),
+		helpers.DefaultGenTxGas,
+		chainID,
+		accNums,
+		accSeqs,
+		priv...,
+	)
+	require.NoError(t, err)
+	txBytes, err := txCfg.TxEncoder()(tx)
+	require.Nil(t, err)
+
+	// Must simulate now as CheckTx doesn't run Msgs anymore
+	_, res, err := app.Simulate(txBytes)
+
+	if expSimPass {
+		require.NoError(t, err)
+		require.NotNil(t, res)
+	} else {
+		require.Error(t, err)
+		require.Nil(t, res)
+	}
+
+	// Simulate a sending a transaction and committing a block
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	gInfo, res, err := app.Deliver(txCfg.TxEncoder(), tx)
+
+	if expPass {
+		require.NoError(t, err)
+		require.NotNil(t, res)
+	} else {
+		require.Error(t, err)
+		require.Nil(t, res)
+	}
+
+	app.EndBlock(abci.RequestEndBlock{Height: header.Height})
+	app.Commit()
+
+	return gInfo, res, err
+}
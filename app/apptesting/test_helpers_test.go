@@ -0,0 +1,92 @@
+package apptesting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func TestSetupWithGenesisValSet(t *testing.T) {
+	app := Setup(t, false)
+	require.Equal(t, int64(1), app.LastBlockHeight())
+
+	ctx := app.BaseApp.NewContext(true, tmproto.Header{})
+	validators := app.StakingKeeper.GetBondedValidatorsByPower(ctx)
+	require.Len(t, validators, 1)
+	require.Equal(t, DefaultBondAmount, validators[0].GetTokens())
+
+	bondedPool := app.StakingKeeper.GetBondedPool(ctx)
+	require.Equal(t, DefaultBondAmount, app.BankKeeper.GetBalance(ctx, bondedPool.GetAddress(), sdk.DefaultBondDenom).Amount)
+
+	// keeper 호출은 열린 블록의 context 에서 하고 commit 되어야 남는다.
+	blockTime := time.Unix(1_700_000_000, 0).UTC()
+	ctx = BeginNextBlock(app, blockTime)
+	addrs := AddTestAddrs(t, app, ctx, 2, sdk.NewInt(1000))
+	EndBlockAndCommit(app)
+	require.Equal(t, int64(2), app.LastBlockHeight())
+
+	for _, addr := range addrs {
+		CheckBalance(t, app, addr, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000)))
+	}
+
+	lastTime := AdvanceBlocks(app, 3, blockTime.Add(5*time.Second), 5*time.Second)
+	require.Equal(t, int64(5), app.LastBlockHeight())
+	require.Equal(t, blockTime.Add(15*time.Second), lastTime)
+}
+
+func TestSignCheckDeliver(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	to := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	acc := authtypes.NewBaseAccount(addr, priv.PubKey(), 0, 0)
+	initCoins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100_000_000))
+	valSet := tmtypes.NewValidatorSet([]*tmtypes.Validator{
+		tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1),
+	})
+
+	app := SetupWithGenesisValSet(t, valSet, []authtypes.GenesisAccount{acc},
+		banktypes.Balance{Address: addr.String(), Coins: initCoins})
+	txCfg := jscapp.MakeEncodingConfig().TxConfig
+
+	ctx := app.BaseApp.NewContext(true, tmproto.Header{})
+	accNum := app.AccountKeeper.GetAccount(ctx, addr).GetAccountNumber()
+
+	sendCoins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000))
+	msgs := []sdk.Msg{banktypes.NewMsgSend(addr, to, sendCoins)}
+
+	header := NewBlockHeader(app, time.Unix(1_700_000_000, 0).UTC())
+	_, _, err := SignCheckDeliver(t, txCfg, app.BaseApp, header, msgs, helpers.TestChainID,
+		[]uint64{accNum}, []uint64{0}, true, true, priv)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), app.LastBlockHeight())
+
+	CheckBalance(t, app, to, sendCoins)
+	CheckBalance(t, app, addr, initCoins.Sub(sendCoins))
+
+	// 이미 쓴 sequence 로 다시 보내면 ante handler 에서 실패한다.
+	header = NewBlockHeader(app, header.Time.Add(5*time.Second))
+	_, _, err = SignCheckDeliver(t, txCfg, app.BaseApp, header, msgs, helpers.TestChainID,
+		[]uint64{accNum}, []uint64{0}, false, false, priv)
+	require.Error(t, err)
+
+	CheckBalance(t, app, to, sendCoins)
+
+	// the validator set is untouched by the txs
+	ctx = app.BaseApp.NewContext(true, tmproto.Header{})
+	validator, found := app.StakingKeeper.GetValidator(ctx, sdk.ValAddress(valSet.Validators[0].Address))
+	require.True(t, found)
+	require.Equal(t, stakingtypes.Bonded, validator.GetStatus())
+}
@@ -0,0 +1,383 @@
+package app_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/Jeongseup/jeongseupchain/app/apptesting"
+	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	"github.com/cosmos/cosmos-sdk/client"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// blockDriver plays the Tendermint side of ABCI for end-to-end app tests.
+// Blocks get synthetic headers signed by the whole validator set, the block
+// time is controlled by the test and validator updates from EndBlock take
+// effect two blocks later, like in Tendermint. Keys are derived from names, so
+// the same scenario always produces the same app hashes.
+type blockDriver struct {
+	t     *testing.T
+	app   *jscapp.JeongseupApp
+	txCfg client.TxConfig
+
+	// time of the next block and the time added after each block
+	blockTime     time.Time
+	blockInterval time.Duration
+
+	// validator set of each height
+	valSets map[int64]*tmtypes.ValidatorSet
+	// app hash committed at each height
+	appHashes map[int64][]byte
+	// txs signed for the next block per signer, to set the sequences
+	pendingTxs map[string]uint64
+}
+
+// testKey returns a deterministic account key for name.
+func testKey(name string) (cryptotypes.PrivKey, sdk.AccAddress) {
+	priv := secp256k1.GenPrivKeyFromSecret([]byte(name))
+	return priv, sdk.AccAddress(priv.PubKey().Address())
+}
+
+// newBlockDriver runs InitChain at genesisTime with numValidators bonded
+// validators delegated by the first genesis account. The first block is not
+// run yet.
+func newBlockDriver(
+	t *testing.T, genesisTime time.Time, numValidators int,
+	genAccs []authtypes.GenesisAccount, balances ...banktypes.Balance,
+) *blockDriver {
+	validators := make([]*tmtypes.Validator, numValidators)
+	for i := range validators {
+		privVal := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("validator-%d", i)))
+		validators[i] = tmtypes.NewValidator(privVal.PubKey(), 1)
+	}
+	valSet := tmtypes.NewValidatorSet(validators)
+
+	encCfg := jscapp.MakeEncodingConfig()
+	app := jscapp.NewJeongseupApp(
+		log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{},
+		jscapp.DefaultNodeHome, 5, encCfg, helpers.EmptyAppOptions{},
+	)
+
+	genesisState := apptesting.GenesisStateWithValSet(t, app, jscapp.NewDefaultGenesisState(encCfg.Marshaler), valSet, genAccs, balances...)
+	stateBytes, err := json.Marshal(genesisState)
+	require.NoError(t, err)
+
+	initValidators := make([]abci.ValidatorUpdate, len(valSet.Validators))
+	for i, val := range valSet.Validators {
+		initValidators[i] = tmtypes.TM2PB.ValidatorUpdate(val)
+	}
+
+	app.InitChain(abci.RequestInitChain{
+		Time:            genesisTime,
+		ChainId:         helpers.TestChainID,
+		ConsensusParams: apptesting.DefaultConsensusParams,
+		Validators:      initValidators,
+		AppStateBytes:   stateBytes,
+		InitialHeight:   1,
+	})
+
+	return &blockDriver{
+		t:             t,
+		app:           app,
+		txCfg:         encCfg.TxConfig,
+		blockTime:     genesisTime,
+		blockInterval: 5 * time.Second,
+		valSets:       map[int64]*tmtypes.ValidatorSet{1: valSet, 2: valSet.Copy()},
+		appHashes:     map[int64][]byte{},
+		pendingTxs:    map[string]uint64{},
+	}
+}
+
+// AdvanceTime moves the time of the next block forward by d.
+func (d *blockDriver) AdvanceTime(dur time.Duration) {
+	d.blockTime = d.blockTime.Add(dur)
+}
+
+// Ctx returns a query context on the last committed state.
+func (d *blockDriver) Ctx() sdk.Context {
+	return d.app.NewContext(true, tmproto.Header{
+		ChainID: helpers.TestChainID,
+		Height:  d.app.LastBlockHeight(),
+		Time:    d.blockTime.Add(-d.blockInterval),
+	})
+}
+
+// ValidatorSet returns the validator set signing the next block.
+func (d *blockDriver) ValidatorSet() *tmtypes.ValidatorSet {
+	return d.valSets[d.app.LastBlockHeight()+1]
+}
+
+// AppHash returns the app hash committed at height.
+func (d *blockDriver) AppHash(height int64) []byte {
+	return d.appHashes[height]
+}
+
+// SignTx signs msgs with priv for the next block. The sequence counts the txs
+// already signed by priv for that block.
+func (d *blockDriver) SignTx(priv cryptotypes.PrivKey, msgs ...sdk.Msg) []byte {
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	acc := d.app.AccountKeeper.GetAccount(d.Ctx(), addr)
+	require.NotNil(d.t, acc, "account %s does not exist", addr)
+
+	seq := acc.GetSequence() + d.pendingTxs[addr.String()]
+	d.pendingTxs[addr.String()]++
+
+	tx, err := helpers.GenTx(
+		d.txCfg, msgs, sdk.NewCoins(), helpers.DefaultGenTxGas/2, helpers.TestChainID,
+		[]uint64{acc.GetAccountNumber()}, []uint64{seq}, priv,
+	)
+	require.NoError(d.t, err)
+
+	txBytes, err := d.txCfg.TxEncoder()(tx)
+	require.NoError(d.t, err)
+
+	return txBytes
+}
+
+// NextBlock runs and commits a block with the given txs and returns their
+// DeliverTx results.
+func (d *blockDriver) NextBlock(txs ...[]byte) []abci.ResponseDeliverTx {
+	height := d.app.LastBlockHeight() + 1
+	valSet := d.valSets[height]
+
+	var votes []abci.VoteInfo
+	if lastValSet, ok := d.valSets[height-1]; ok {
+		for _, val := range lastValSet.Validators {
+			votes = append(votes, abci.VoteInfo{
+				Validator:       abci.Validator{Address: val.Address, Power: val.VotingPower},
+				SignedLastBlock: true,
+			})
+		}
+	}
+
+	header := tmproto.Header{
+		ChainID:            helpers.TestChainID,
+		Height:             height,
+		Time:               d.blockTime,
+		AppHash:            d.app.LastCommitID().Hash,
+		ValidatorsHash:     valSet.Hash(),
+		NextValidatorsHash: d.valSets[height+1].Hash(),
+		ProposerAddress:    valSet.GetProposer().Address,
+	}
+
+	d.app.BeginBlock(abci.RequestBeginBlock{
+		Header:         header,
+		LastCommitInfo: abci.LastCommitInfo{Votes: votes},
+	})
+
+	results := make([]abci.ResponseDeliverTx, len(txs))
+	for i, tx := range txs {
+		results[i] = d.app.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+	}
+
+	res := d.app.EndBlock(abci.RequestEndBlock{Height: height})
+	d.applyValidatorUpdates(height, res.ValidatorUpdates)
+
+	commit := d.app.Commit()
+	d.appHashes[height] = commit.Data
+
+	d.blockTime = d.blockTime.Add(d.blockInterval)
+	d.pendingTxs = map[string]uint64{}
+
+	return results
+}
+
+// NextBlocks runs n empty blocks.
+func (d *blockDriver) NextBlocks(n int) {
+	for i := 0; i < n; i++ {
+		d.NextBlock()
+	}
+}
+
+// Deliver runs a block with a single tx of msgs signed by priv and requires
+// the tx to succeed.
+func (d *blockDriver) Deliver(priv cryptotypes.PrivKey, msgs ...sdk.Msg) abci.ResponseDeliverTx {
+	res := d.NextBlock(d.SignTx(priv, msgs...))[0]
+	require.True(d.t, res.IsOK(), "tx failed: %s", res.Log)
+
+	return res
+}
+
+// applyValidatorUpdates sets the validator set of height+2, since Tendermint
+// applies the updates returned at EndBlock of height from height+2.
+func (d *blockDriver) applyValidatorUpdates(height int64, updates []abci.ValidatorUpdate) {
+	next := d.valSets[height+1].Copy()
+	if len(updates) > 0 {
+		changes, err := tmtypes.PB2TM.ValidatorUpdates(updates)
+		require.NoError(d.t, err)
+		require.NoError(d.t, next.UpdateWithChangeSet(changes))
+	}
+	d.valSets[height+2] = next
+}
+
+func TestBlockDriverUnbondingCompletes(t *testing.T) {
+	genesisTime := time.Unix(1_700_000_000, 0).UTC()
+	delegatorPriv, delegator := testKey("delegator")
+	initCoins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100_000_000))
+
+	d := newBlockDriver(t, genesisTime, 1,
+		[]authtypes.GenesisAccount{authtypes.NewBaseAccountWithAddress(delegator)},
+		banktypes.Balance{Address: delegator.String(), Coins: initCoins},
+	)
+	d.NextBlock()
+
+	valAddr := sdk.ValAddress(d.ValidatorSet().Validators[0].Address)
+	bond := sdk.NewInt64Coin(sdk.DefaultBondDenom, 10_000_000)
+	d.Deliver(delegatorPriv, stakingtypes.NewMsgDelegate(delegator, valAddr, bond))
+	d.Deliver(delegatorPriv, stakingtypes.NewMsgUndelegate(delegator, valAddr, bond))
+
+	ctx := d.Ctx()
+	ubd, found := d.app.StakingKeeper.GetUnbondingDelegation(ctx, delegator, valAddr)
+	require.True(t, found)
+	require.Len(t, ubd.Entries, 1)
+
+	// undelegating withdraws the rewards of the delegation as well
+	balance := d.app.BankKeeper.GetAllBalances(ctx, delegator)
+	require.True(t, balance.IsAllLT(initCoins))
+
+	// one block before the completion time the tokens are still unbonding
+	unbondingTime := d.app.StakingKeeper.UnbondingTime(ctx)
+	d.AdvanceTime(unbondingTime - 2*d.blockInterval)
+	d.NextBlock()
+	_, found = d.app.StakingKeeper.GetUnbondingDelegation(d.Ctx(), delegator, valAddr)
+	require.True(t, found)
+
+	d.AdvanceTime(d.blockInterval)
+	d.NextBlock()
+
+	ctx = d.Ctx()
+	_, found = d.app.StakingKeeper.GetUnbondingDelegation(ctx, delegator, valAddr)
+	require.False(t, found)
+	require.Equal(t, balance.Add(bond), d.app.BankKeeper.GetAllBalances(ctx, delegator))
+}
+
+func TestBlockDriverValidatorSetUpdates(t *testing.T) {
+	genesisTime := time.Unix(1_700_000_000, 0).UTC()
+	_, delegator := testKey("delegator")
+	operatorPriv, operator := testKey("operator")
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100_000_000))
+
+	d := newBlockDriver(t, genesisTime, 1,
+		[]authtypes.GenesisAccount{
+			authtypes.NewBaseAccountWithAddress(delegator),
+			authtypes.NewBaseAccountWithAddress(operator),
+		},
+		banktypes.Balance{Address: delegator.String(), Coins: coins},
+		banktypes.Balance{Address: operator.String(), Coins: coins},
+	)
+	d.NextBlock()
+
+	consPriv := ed25519.GenPrivKeyFromSecret([]byte("new-validator"))
+	consPubKey, err := cryptocodec.FromTmPubKeyInterface(consPriv.PubKey())
+	require.NoError(t, err)
+
+	msg, err := stakingtypes.NewMsgCreateValidator(
+		sdk.ValAddress(operator), consPubKey, sdk.NewInt64Coin(sdk.DefaultBondDenom, 2_000_000),
+		stakingtypes.NewDescription("new-validator", "", "", "", ""),
+		stakingtypes.NewCommissionRates(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2)),
+		sdk.OneInt(),
+	)
+	require.NoError(t, err)
+
+	// the update returned at EndBlock of height H is applied from H+2
+	d.Deliver(operatorPriv, msg)
+	require.Len(t, d.ValidatorSet().Validators, 1)
+
+	d.NextBlock()
+	require.Len(t, d.ValidatorSet().Validators, 2)
+
+	_, newVal := d.ValidatorSet().GetByAddress(consPriv.PubKey().Address())
+	require.NotNil(t, newVal)
+	require.Equal(t, int64(2), newVal.VotingPower)
+
+	// the new validator signs from the next block on
+	d.NextBlocks(3)
+	info, found := d.app.SlashingKeeper.GetValidatorSigningInfo(d.Ctx(), sdk.ConsAddress(consPriv.PubKey().Address()))
+	require.True(t, found)
+	require.Equal(t, int64(0), info.MissedBlocksCounter)
+	require.Positive(t, info.IndexOffset)
+}
+
+func TestBlockDriverVestingUnlock(t *testing.T) {
+	genesisTime := time.Unix(1_700_000_000, 0).UTC()
+	_, delegator := testKey("delegator")
+	vestingPriv, vestingAddr := testKey("vesting")
+	_, to := testKey("recipient")
+
+	vestingCoins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000))
+	vestingAcc := vestingtypes.NewContinuousVestingAccount(
+		authtypes.NewBaseAccountWithAddress(vestingAddr), vestingCoins,
+		genesisTime.Unix(), genesisTime.Add(100*time.Second).Unix(),
+	)
+
+	d := newBlockDriver(t, genesisTime, 1,
+		[]authtypes.GenesisAccount{authtypes.NewBaseAccountWithAddress(delegator), vestingAcc},
+		banktypes.Balance{Address: delegator.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100_000_000))},
+		banktypes.Balance{Address: vestingAddr.String(), Coins: vestingCoins},
+	)
+	d.NextBlock()
+
+	// at 50s only about half is vested, so sending everything fails
+	d.AdvanceTime(45 * time.Second)
+	res := d.NextBlock(d.SignTx(vestingPriv, banktypes.NewMsgSend(vestingAddr, to, vestingCoins)))[0]
+	require.False(t, res.IsOK())
+	require.Contains(t, res.Log, "insufficient funds")
+
+	part := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 400))
+	d.Deliver(vestingPriv, banktypes.NewMsgSend(vestingAddr, to, part))
+
+	// after the end time the rest is spendable
+	d.AdvanceTime(100 * time.Second)
+	d.Deliver(vestingPriv, banktypes.NewMsgSend(vestingAddr, to, vestingCoins.Sub(part)))
+
+	ctx := d.Ctx()
+	require.True(t, d.app.BankKeeper.GetAllBalances(ctx, vestingAddr).IsZero())
+	require.Equal(t, vestingCoins, d.app.BankKeeper.GetAllBalances(ctx, to))
+}
+
+func TestBlockDriverDeterministicAppHash(t *testing.T) {
+	genesisTime := time.Unix(1_700_000_000, 0).UTC()
+
+	run := func() *blockDriver {
+		senderPriv, sender := testKey("sender")
+		_, to := testKey("recipient")
+		coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100_000_000))
+
+		d := newBlockDriver(t, genesisTime, 2,
+			[]authtypes.GenesisAccount{authtypes.NewBaseAccountWithAddress(sender)},
+			banktypes.Balance{Address: sender.String(), Coins: coins},
+		)
+		d.NextBlock()
+
+		send := banktypes.NewMsgSend(sender, to, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10)))
+		d.NextBlock(d.SignTx(senderPriv, send), d.SignTx(senderPriv, send))
+		d.NextBlocks(3)
+
+		return d
+	}
+
+	d1, d2 := run(), run()
+	require.Equal(t, d1.app.LastBlockHeight(), d2.app.LastBlockHeight())
+	for height := int64(1); height <= d1.app.LastBlockHeight(); height++ {
+		require.NotEmpty(t, d1.AppHash(height))
+		require.Equal(t, d1.AppHash(height), d2.AppHash(height), "app hash differs at height %d", height)
+	}
+}
@@ -1,6 +1,5 @@
 package app
 
 const (
-	appName     = "Jeongseup"
-	upgradeName = "Genesis"
+	appName = "Jeongseup"
 )
@@ -16,8 +16,13 @@ import (
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
 	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/gorilla/mux"
 	"github.com/rakyll/statik/fs"
@@ -50,11 +55,13 @@ func (app *JeongseupApp) InitChainer(ctx sdk.Context, req abci.RequestInitChain)
 		panic(err)
 	}
 
-	// app.UpgradeKeeper 이걸 안하면 어떻게 될까?
-	// app.UpgradeKeeper.SetModuleVersionMap(ctx, app.mm.GetVersionMap())
+	// 이걸 안하면 첫 upgrade 때 모든 모듈이 version 0 에서부터 migration을 돌게 됨
+	app.UpgradeKeeper.SetModuleVersionMap(ctx, app.mm.GetVersionMap())
 
-	app.Logger().Info("THIS IS GENESIS BLOCK APP HASH", string(app.mm.InitGenesis(ctx, app.appCodec, genesisState).AppHash))
-	return app.mm.InitGenesis(ctx, app.appCodec, genesisState)
+	// InitGenesis는 gentx를 deliver하기 때문에 한번만 호출해야 함
+	res := app.mm.InitGenesis(ctx, app.appCodec, genesisState)
+	app.Logger().Info("THIS IS GENESIS BLOCK APP HASH", "app_hash", string(res.AppHash))
+	return res
 }
 
 // for export command
@@ -65,10 +72,7 @@ func (app *JeongseupApp) LoadHeight(height int64) error {
 
 // ModuleAccountAddrs returns all the app's module account addresses.
 func (app *JeongseupApp) ModuleAccountAddrs() map[string]bool {
-	modAccAddrs := make(map[string]bool)
-	for acc := range moduleAccountPermissions {
-		modAccAddrs[authtypes.NewModuleAddress(acc).String()] = true
-	}
+	modAccAddrs := GetModuleAccountAddrs()
 
 	stdlog.Printf("module account address: %v", modAccAddrs)
 	return modAccAddrs
@@ -94,6 +98,13 @@ func (app *JeongseupApp) GetKey(storeKey string) *sdk.KVStoreKey {
 	return app.keys[storeKey]
 }
 
+// GetKeys returns the KVStoreKeys of all stores by store name.
+//
+// NOTE: This is solely to be used for testing purposes.
+func (app *JeongseupApp) GetKeys() map[string]*sdk.KVStoreKey {
+	return app.keys
+}
+
 // GetTKey returns the TransientStoreKey for the provided store key.
 //
 // NOTE: This is solely to be used for testing purposes.
@@ -177,7 +188,27 @@ func (app *JeongseupApp) RegisterTendermintService(clientCtx client.Context) {
 	tmservice.RegisterTendermintService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.interfaceRegistry)
 }
 
-// for testing GetMaccPerms()
+// GetModuleAccountAddrs returns the module account addresses of the app
+// without an app instance (genesis audit 등 CLI 에서 사용)
+func GetModuleAccountAddrs() map[string]bool {
+	modAccAddrs := make(map[string]bool)
+	for acc := range moduleAccountPermissions {
+		modAccAddrs[authtypes.NewModuleAddress(acc).String()] = true
+	}
+
+	return modAccAddrs
+}
+
+// GetMaccPerms returns a copy of the module account permissions
+// (add-genesis-account, tests 에서 사용)
+func GetMaccPerms() map[string][]string {
+	dupMaccPerms := make(map[string][]string)
+	for k, v := range moduleAccountPermissions {
+		dupMaccPerms[k] = v
+	}
+
+	return dupMaccPerms
+}
 
 // initParamsKeeper init params keeper and its subspaces
 func initParamsKeeper(
@@ -190,6 +221,11 @@ func initParamsKeeper(
 	paramsKeeper.Subspace(authtypes.ModuleName)
 	paramsKeeper.Subspace(banktypes.ModuleName)
 	paramsKeeper.Subspace(stakingtypes.ModuleName)
+	paramsKeeper.Subspace(minttypes.ModuleName)
+	paramsKeeper.Subspace(distrtypes.ModuleName)
+	paramsKeeper.Subspace(slashingtypes.ModuleName)
+	paramsKeeper.Subspace(govtypes.ModuleName).WithKeyTable(govtypes.ParamKeyTable())
+	paramsKeeper.Subspace(crisistypes.ModuleName)
 
 	return paramsKeeper
 }
@@ -1,53 +1,221 @@
 package app
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
-	"log"
+	"fmt"
+	"io"
+	"sort"
 
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
+// ExportOptions configures ExportAppStateTo.
+type ExportOptions struct {
+	ForZeroHeight    bool
+	JailAllowedAddrs []string
+
+	// Modules limits the exported app state to the given modules. All modules
+	// are exported when empty.
+	Modules []string
+
+	// Prefix and Indent work as in json.MarshalIndent. An empty Indent writes
+	// compact JSON.
+	Prefix string
+	Indent string
+}
+
 // ExportAppStateAndValidators exports the state of the application for a genesis
 // file.
 func (app *JeongseupApp) ExportAppStateAndValidators(
 	forZeroHeight bool, jailAllowedAddrs []string,
 ) (servertypes.ExportedApp, error) {
+	var appState bytes.Buffer
+	exported, err := app.ExportAppStateTo(&appState, ExportOptions{
+		ForZeroHeight:    forZeroHeight,
+		JailAllowedAddrs: jailAllowedAddrs,
+		Indent:           "  ",
+	})
+	if err != nil {
+		return servertypes.ExportedApp{}, err
+	}
+
+	exported.AppState = appState.Bytes()
+	return exported, nil
+}
+
+// ExportAppStateTo writes the app state to w one module at a time, so only a
+// single module genesis is held in memory instead of the whole app state.
+// The returned ExportedApp carries everything but the AppState.
+func (app *JeongseupApp) ExportAppStateTo(w io.Writer, opts ExportOptions) (servertypes.ExportedApp, error) {
+	export, err := app.PrepareExport(opts)
+	if err != nil {
+		return servertypes.ExportedApp{}, err
+	}
+
+	return export.ExportedApp, export.WriteAppState(w)
+}
+
+// AppStateExport is an export whose checks, zero height preparation and
+// validator set already succeeded, so callers can write their own framing
+// around the app state only once nothing but writing is left.
+type AppStateExport struct {
+	// ExportedApp carries everything but the AppState.
+	servertypes.ExportedApp
+
+	app     *JeongseupApp
+	ctx     sdk.Context
+	modules []string
+	opts    ExportOptions
+}
+
+// PrepareExport runs everything of an export that can fail on the app state
+// itself, without writing anything.
+func (app *JeongseupApp) PrepareExport(opts ExportOptions) (*AppStateExport, error) {
+	modules, err := app.exportModuleNames(opts.Modules)
+	if err != nil {
+		return nil, err
+	}
+
 	// as if they could withdraw from the start of the next block
 	ctx := app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight()})
 
 	// We export at last height + 1, because that's the height at which
 	// Tendermint will start InitChain.
 	height := app.LastBlockHeight() + 1
-	if forZeroHeight {
+	if opts.ForZeroHeight {
 		height = 0
-		app.prepForZeroHeightGenesis(ctx, jailAllowedAddrs)
+		if err := app.prepForZeroHeightGenesis(ctx, opts.JailAllowedAddrs); err != nil {
+			return nil, fmt.Errorf("failed to prepare zero height genesis: %w", err)
+		}
 	}
 
-	genState := app.mm.ExportGenesis(ctx, app.appCodec)
-	appState, err := json.MarshalIndent(genState, "", "  ")
+	validators, err := staking.WriteValidators(ctx, app.StakingKeeper)
 	if err != nil {
-		return servertypes.ExportedApp{}, err
+		return nil, err
 	}
 
-	validators, err := staking.WriteValidators(ctx, app.StakingKeeper)
-	return servertypes.ExportedApp{
-		AppState:        appState,
-		Validators:      validators,
-		Height:          height,
-		ConsensusParams: app.BaseApp.GetConsensusParams(ctx),
-	}, err
+	return &AppStateExport{
+		ExportedApp: servertypes.ExportedApp{
+			Validators:      validators,
+			Height:          height,
+			ConsensusParams: app.BaseApp.GetConsensusParams(ctx),
+		},
+		app:     app,
+		ctx:     ctx,
+		modules: modules,
+		opts:    opts,
+	}, nil
+}
+
+// WriteAppState writes the app state of the prepared export to w.
+func (e *AppStateExport) WriteAppState(w io.Writer) error {
+	return e.app.writeAppState(e.ctx, w, e.modules, e.opts.Prefix, e.opts.Indent)
+}
+
+// exportModuleNames returns the sorted module names to export, the same key
+// order json.Marshal uses for the full genesis map.
+func (app *JeongseupApp) exportModuleNames(modules []string) ([]string, error) {
+	if len(modules) == 0 {
+		modules = app.mm.OrderExportGenesis
+	}
+
+	names := make([]string, 0, len(modules))
+	seen := make(map[string]bool, len(modules))
+	for _, name := range modules {
+		if _, ok := app.mm.Modules[name]; !ok {
+			return nil, fmt.Errorf("unknown module %q", name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// writeAppState writes the genesis of each module as one JSON object, with
+// the same layout json.MarshalIndent gives for map[string]json.RawMessage.
+func (app *JeongseupApp) writeAppState(ctx sdk.Context, w io.Writer, modules []string, prefix, indent string) error {
+	bw := bufio.NewWriter(w)
+
+	var (
+		open   = "{"
+		sep    = ","
+		colon  = ":"
+		close  = "}"
+		nested = prefix + indent
+	)
+	if indent != "" {
+		open = "{\n" + nested
+		sep = ",\n" + nested
+		colon = ": "
+		close = "\n" + prefix + "}"
+	}
+
+	if _, err := bw.WriteString(open); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for i, name := range modules {
+		if i > 0 {
+			if _, err := bw.WriteString(sep); err != nil {
+				return err
+			}
+		}
+
+		buf.Reset()
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteString(colon)
+
+		moduleState := app.mm.Modules[name].ExportGenesis(ctx, app.appCodec)
+		if len(moduleState) == 0 {
+			buf.WriteString("null")
+		} else if indent != "" {
+			if err := json.Indent(&buf, moduleState, nested, indent); err != nil {
+				return fmt.Errorf("failed to encode %s genesis: %w", name, err)
+			}
+		} else if err := json.Compact(&buf, moduleState); err != nil {
+			return fmt.Errorf("failed to encode %s genesis: %w", name, err)
+		}
+
+		if _, err := buf.WriteTo(bw); err != nil {
+			return err
+		}
+
+		// 모듈 단위로 내보내서 전체 state 가 메모리에 쌓이지 않게 한다.
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if _, err := bw.WriteString(close); err != nil {
+		return err
+	}
+
+	return bw.Flush()
 }
 
 // prepare for fresh start at zero height
 // NOTE zero height genesis is a temporary feature which will be deprecated
 //
 //	in favour of export at a block height
-func (app *JeongseupApp) prepForZeroHeightGenesis(ctx sdk.Context, jailAllowedAddrs []string) {
+//
+// The allow list is validated before any state is changed, so an invalid
+// address leaves the app untouched. A broken invariant is returned as an
+// error as well.
+func (app *JeongseupApp) prepForZeroHeightGenesis(ctx sdk.Context, jailAllowedAddrs []string) error {
 	applyAllowedAddrs := false
 
 	// check if there is a allowed address list
@@ -58,21 +226,22 @@ func (app *JeongseupApp) prepForZeroHeightGenesis(ctx sdk.Context, jailAllowedAd
 	allowedAddrsMap := make(map[string]bool)
 
 	for _, addr := range jailAllowedAddrs {
-		_, err := sdk.ValAddressFromBech32(addr)
-		if err != nil {
-			log.Fatal(err)
+		if _, err := sdk.ValAddressFromBech32(addr); err != nil {
+			return fmt.Errorf("invalid jail allowed address %q: %w", addr, err)
 		}
 		allowedAddrsMap[addr] = true
 	}
 
 	/* Just to be safe, assert the invariants on current state. */
-	// app.CrisisKeeper.AssertInvariants(ctx)
+	if err := app.assertInvariants(ctx); err != nil {
+		return err
+	}
 
 	/* Handle fee distribution state. */
 
 	// withdraw all validator commission
 	app.StakingKeeper.IterateValidators(ctx, func(_ int64, val stakingtypes.ValidatorI) (stop bool) {
-		// _, _ = app.DistrKeeper.WithdrawValidatorCommission(ctx, val.GetOperator())
+		_, _ = app.DistrKeeper.WithdrawValidatorCommission(ctx, val.GetOperator())
 		return false
 	})
 
@@ -81,23 +250,22 @@ func (app *JeongseupApp) prepForZeroHeightGenesis(ctx sdk.Context, jailAllowedAd
 	for _, delegation := range dels {
 		valAddr, err := sdk.ValAddressFromBech32(delegation.ValidatorAddress)
 		if err != nil {
-			panic(err)
+			return err
 		}
 
 		delAddr, err := sdk.AccAddressFromBech32(delegation.DelegatorAddress)
 		if err != nil {
-			panic(err)
+			return err
 		}
 
-		_, _ = valAddr, delAddr
-		// _, _ = app.DistrKeeper.WithdrawDelegationRewards(ctx, delAddr, valAddr)
+		_, _ = app.DistrKeeper.WithdrawDelegationRewards(ctx, delAddr, valAddr)
 	}
 
 	// clear validator slash events
-	// app.DistrKeeper.DeleteAllValidatorSlashEvents(ctx)
+	app.DistrKeeper.DeleteAllValidatorSlashEvents(ctx)
 
 	// clear validator historical rewards
-	// app.DistrKeeper.DeleteAllValidatorHistoricalRewards(ctx)
+	app.DistrKeeper.DeleteAllValidatorHistoricalRewards(ctx)
 
 	// set context height to zero
 	height := ctx.BlockHeight()
@@ -106,12 +274,12 @@ func (app *JeongseupApp) prepForZeroHeightGenesis(ctx sdk.Context, jailAllowedAd
 	// reinitialize all validators
 	app.StakingKeeper.IterateValidators(ctx, func(_ int64, val stakingtypes.ValidatorI) (stop bool) {
 		// donate any unwithdrawn outstanding reward fraction tokens to the community pool
-		// scraps := app.DistrKeeper.GetValidatorOutstandingRewardsCoins(ctx, val.GetOperator())
-		// feePool := app.DistrKeeper.GetFeePool(ctx)
-		// feePool.CommunityPool = feePool.CommunityPool.Add(scraps...)
-		// app.DistrKeeper.SetFeePool(ctx, feePool)
+		scraps := app.DistrKeeper.GetValidatorOutstandingRewardsCoins(ctx, val.GetOperator())
+		feePool := app.DistrKeeper.GetFeePool(ctx)
+		feePool.CommunityPool = feePool.CommunityPool.Add(scraps...)
+		app.DistrKeeper.SetFeePool(ctx, feePool)
 
-		// app.DistrKeeper.Hooks().AfterValidatorCreated(ctx, val.GetOperator())
+		app.DistrKeeper.Hooks().AfterValidatorCreated(ctx, val.GetOperator())
 		return false
 	})
 
@@ -119,16 +287,15 @@ func (app *JeongseupApp) prepForZeroHeightGenesis(ctx sdk.Context, jailAllowedAd
 	for _, del := range dels {
 		valAddr, err := sdk.ValAddressFromBech32(del.ValidatorAddress)
 		if err != nil {
-			panic(err)
+			return err
 		}
 		delAddr, err := sdk.AccAddressFromBech32(del.DelegatorAddress)
 		if err != nil {
-			panic(err)
+			return err
 		}
 
-		_, _ = valAddr, delAddr
-		// app.DistrKeeper.Hooks().BeforeDelegationCreated(ctx, delAddr, valAddr)
-		// app.DistrKeeper.Hooks().AfterDelegationModified(ctx, delAddr, valAddr)
+		app.DistrKeeper.Hooks().BeforeDelegationCreated(ctx, delAddr, valAddr)
+		app.DistrKeeper.Hooks().AfterDelegationModified(ctx, delAddr, valAddr)
 	}
 
 	// reset context height
@@ -164,7 +331,8 @@ func (app *JeongseupApp) prepForZeroHeightGenesis(ctx sdk.Context, jailAllowedAd
 		addr := sdk.ValAddress(stakingtypes.AddressFromValidatorsKey(iter.Key()))
 		validator, found := app.StakingKeeper.GetValidator(ctx, addr)
 		if !found {
-			panic("expected validator, not found")
+			iter.Close()
+			return fmt.Errorf("expected validator %s, not found", addr)
 		}
 
 		validator.UnbondingHeight = 0
@@ -178,20 +346,34 @@ func (app *JeongseupApp) prepForZeroHeightGenesis(ctx sdk.Context, jailAllowedAd
 
 	iter.Close()
 
-	_, err := app.StakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
-	if err != nil {
-		log.Fatal(err)
+	if _, err := app.StakingKeeper.ApplyAndReturnValidatorSetUpdates(ctx); err != nil {
+		return err
 	}
 
 	/* Handle slashing state. */
 
 	// reset start height on signing infos
-	// app.SlashingKeeper.IterateValidatorSigningInfos(
-	// 	ctx,
-	// 	func(addr sdk.ConsAddress, info slashingtypes.ValidatorSigningInfo) (stop bool) {
-	// 		info.StartHeight = 0
-	// 		app.SlashingKeeper.SetValidatorSigningInfo(ctx, addr, info)
-	// 		return false
-	// 	},
-	// )
+	app.SlashingKeeper.IterateValidatorSigningInfos(
+		ctx,
+		func(addr sdk.ConsAddress, info slashingtypes.ValidatorSigningInfo) (stop bool) {
+			info.StartHeight = 0
+			app.SlashingKeeper.SetValidatorSigningInfo(ctx, addr, info)
+			return false
+		},
+	)
+
+	return nil
+}
+
+// assertInvariants runs the registered invariants and returns a broken one as
+// an error instead of the panic of CrisisKeeper.AssertInvariants.
+func (app *JeongseupApp) assertInvariants(ctx sdk.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invariant broken: %v", r)
+		}
+	}()
+
+	app.CrisisKeeper.AssertInvariants(ctx)
+	return nil
 }
@@ -0,0 +1,251 @@
+package app_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/Jeongseup/jeongseupchain/app/apptesting"
+	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+const zeroHeightTestChainID = "zero-height-test"
+
+// newZeroHeightTestApp starts a chain with one bonded validator and runs the
+// given number of blocks signed by it, so distribution and slashing state
+// exist when exporting.
+func newZeroHeightTestApp(t *testing.T, blocks int64) (*jscapp.JeongseupApp, *tmtypes.Validator) {
+	encCfg := jscapp.MakeEncodingConfig()
+	app := jscapp.NewJeongseupApp(
+		log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{},
+		jscapp.DefaultNodeHome, 0, encCfg, helpers.EmptyAppOptions{},
+	)
+
+	val := tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1)
+	delegator := authtypes.NewBaseAccountWithAddress(sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()))
+	bondAmt := sdk.TokensFromConsensusPower(1, sdk.DefaultPowerReduction)
+
+	pk, err := cryptocodec.FromTmPubKeyInterface(val.PubKey)
+	require.NoError(t, err)
+	pkAny, err := codectypes.NewAnyWithValue(pk)
+	require.NoError(t, err)
+
+	genesisState := jscapp.NewDefaultGenesisState(encCfg.Marshaler)
+	genesisState[authtypes.ModuleName] = encCfg.Marshaler.MustMarshalJSON(
+		authtypes.NewGenesisState(authtypes.DefaultParams(), authtypes.GenesisAccounts{delegator}))
+
+	validator := stakingtypes.Validator{
+		OperatorAddress:   sdk.ValAddress(val.Address).String(),
+		ConsensusPubkey:   pkAny,
+		Status:            stakingtypes.Bonded,
+		Tokens:            bondAmt,
+		DelegatorShares:   bondAmt.ToDec(),
+		UnbondingTime:     time.Unix(0, 0).UTC(),
+		Commission:        stakingtypes.NewCommission(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.ZeroDec()),
+		MinSelfDelegation: sdk.ZeroInt(),
+	}
+	delegation := stakingtypes.NewDelegation(delegator.GetAddress(), sdk.ValAddress(val.Address), bondAmt.ToDec())
+	genesisState[stakingtypes.ModuleName] = encCfg.Marshaler.MustMarshalJSON(stakingtypes.NewGenesisState(
+		stakingtypes.DefaultParams(), []stakingtypes.Validator{validator}, []stakingtypes.Delegation{delegation}))
+
+	// a validator bonded in genesis skips the AfterValidatorBonded hook, so
+	// its signing info has to be in genesis as well
+	consAddr := sdk.ConsAddress(val.Address)
+	genesisState[slashingtypes.ModuleName] = encCfg.Marshaler.MustMarshalJSON(slashingtypes.NewGenesisState(
+		slashingtypes.DefaultParams(),
+		[]slashingtypes.SigningInfo{{
+			Address:              consAddr.String(),
+			ValidatorSigningInfo: slashingtypes.NewValidatorSigningInfo(consAddr, 0, 0, time.Unix(0, 0).UTC(), false, 0),
+		}},
+		[]slashingtypes.ValidatorMissedBlocks{},
+	))
+
+	delegatorCoins := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, sdk.NewInt(100_000_000)))
+	bondedCoins := sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, bondAmt))
+	balances := []banktypes.Balance{
+		{Address: delegator.GetAddress().String(), Coins: delegatorCoins},
+		{Address: authtypes.NewModuleAddress(stakingtypes.BondedPoolName).String(), Coins: bondedCoins},
+	}
+	genesisState[banktypes.ModuleName] = encCfg.Marshaler.MustMarshalJSON(banktypes.NewGenesisState(
+		banktypes.DefaultGenesisState().Params, balances, delegatorCoins.Add(bondedCoins...), []banktypes.Metadata{}))
+
+	stateBytes, err := json.MarshalIndent(genesisState, "", "  ")
+	require.NoError(t, err)
+
+	app.InitChain(abci.RequestInitChain{
+		ChainId:         zeroHeightTestChainID,
+		ConsensusParams: tmtypes.TM2PB.ConsensusParams(tmtypes.DefaultConsensusParams()),
+		Validators:      []abci.ValidatorUpdate{},
+		AppStateBytes:   stateBytes,
+	})
+
+	for height := int64(1); height <= blocks; height++ {
+		header := tmproto.Header{
+			ChainID:         zeroHeightTestChainID,
+			Height:          height,
+			Time:            time.Unix(height*5, 0).UTC(),
+			ProposerAddress: val.Address,
+		}
+		app.BeginBlock(abci.RequestBeginBlock{
+			Header: header,
+			LastCommitInfo: abci.LastCommitInfo{Votes: []abci.VoteInfo{{
+				Validator:       abci.Validator{Address: val.Address, Power: val.VotingPower},
+				SignedLastBlock: true,
+			}}},
+		})
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+	}
+
+	return app, val
+}
+
+func TestZeroHeightExportImport(t *testing.T) {
+	app, val := newZeroHeightTestApp(t, 10)
+	valAddr := sdk.ValAddress(val.Address)
+
+	// an invalid allow list is an error and must not touch the state
+	before := apptesting.SnapshotStores(app)
+	_, err := app.ExportAppStateAndValidators(true, []string{"invalid"})
+	require.Error(t, err)
+	apptesting.RequireStoresEqual(t, before, app)
+
+	exported, err := app.ExportAppStateAndValidators(true, []string{valAddr.String()})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), exported.Height)
+	require.Len(t, exported.Validators, 1)
+	require.Equal(t, val.PubKey, exported.Validators[0].PubKey)
+	require.Equal(t, val.VotingPower, exported.Validators[0].Power)
+
+	var genesisState jscapp.GenesisState
+	require.NoError(t, json.Unmarshal(exported.AppState, &genesisState))
+	require.NoError(t, jscapp.ModuleBasics.ValidateGenesis(app.AppCodec(), jscapp.MakeEncodingConfig().TxConfig, genesisState))
+
+	// import into a fresh app; InitChain panics if the validator set returned
+	// by InitGenesis differs from the exported one.
+	exportedValidators := make([]abci.ValidatorUpdate, len(exported.Validators))
+	for i, v := range exported.Validators {
+		exportedValidators[i] = tmtypes.TM2PB.ValidatorUpdate(tmtypes.NewValidator(v.PubKey, v.Power))
+	}
+
+	newApp := jscapp.NewJeongseupApp(
+		log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{},
+		jscapp.DefaultNodeHome, 0, jscapp.MakeEncodingConfig(), helpers.EmptyAppOptions{},
+	)
+
+	var res abci.ResponseInitChain
+	require.NotPanics(t, func() {
+		res = newApp.InitChain(abci.RequestInitChain{
+			ChainId:         zeroHeightTestChainID,
+			ConsensusParams: exported.ConsensusParams,
+			Validators:      exportedValidators,
+			AppStateBytes:   exported.AppState,
+		})
+	})
+	require.Equal(t, exportedValidators, res.Validators)
+
+	// the restarted chain keeps producing blocks with the same validator
+	require.NotPanics(t, func() {
+		newApp.BeginBlock(abci.RequestBeginBlock{Header: tmproto.Header{
+			ChainID:         zeroHeightTestChainID,
+			Height:          1,
+			ProposerAddress: val.Address,
+		}})
+		newApp.EndBlock(abci.RequestEndBlock{Height: 1})
+		newApp.Commit()
+	})
+
+	reexported, err := newApp.ExportAppStateAndValidators(false, []string{})
+	require.NoError(t, err)
+	require.Equal(t, exported.Validators, reexported.Validators)
+
+	ctx := newApp.NewContext(true, tmproto.Header{})
+	validator, found := newApp.StakingKeeper.GetValidator(ctx, valAddr)
+	require.True(t, found)
+	require.False(t, validator.IsJailed())
+	require.Equal(t, int64(0), validator.UnbondingHeight)
+}
+
+func TestZeroHeightExportBrokenInvariant(t *testing.T) {
+	app, _ := newZeroHeightTestApp(t, 3)
+
+	// community pool 에 distribution module account 에 없는 coin 을 넣어 invariant 를 깬다.
+	ctx := app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight()})
+	feePool := app.DistrKeeper.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Add(sdk.NewInt64DecCoin(sdk.DefaultBondDenom, 1000))
+	app.DistrKeeper.SetFeePool(ctx, feePool)
+
+	var err error
+	require.NotPanics(t, func() {
+		_, err = app.ExportAppStateAndValidators(true, []string{})
+	})
+	require.ErrorContains(t, err, "invariant broken")
+}
+
+func TestExportImportStores(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	acc := authtypes.NewBaseAccount(addr, priv.PubKey(), 0, 0)
+	val := tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1)
+	valAddr := sdk.ValAddress(val.Address)
+
+	app := apptesting.SetupWithGenesisValSet(t, tmtypes.NewValidatorSet([]*tmtypes.Validator{val}),
+		[]authtypes.GenesisAccount{acc},
+		banktypes.Balance{Address: addr.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100_000_000))},
+	)
+	txCfg := jscapp.MakeEncodingConfig().TxConfig
+	ctx := app.NewContext(true, tmproto.Header{})
+	accNum := app.AccountKeeper.GetAccount(ctx, addr).GetAccountNumber()
+
+	// send, delegate and undelegate so that new accounts, delegations and an
+	// unbonding queue entry are in the exported state
+	blockTime := time.Unix(1_700_000_000, 0).UTC()
+	bond := sdk.NewInt64Coin(sdk.DefaultBondDenom, 5_000_000)
+	msgs := [][]sdk.Msg{
+		{banktypes.NewMsgSend(addr, sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address()), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000)))},
+		{stakingtypes.NewMsgDelegate(addr, valAddr, bond)},
+		{stakingtypes.NewMsgUndelegate(addr, valAddr, sdk.NewInt64Coin(sdk.DefaultBondDenom, 1_000_000))},
+	}
+	for seq, msg := range msgs {
+		blockTime = blockTime.Add(5 * time.Second)
+		apptesting.SignCheckDeliver(t, txCfg, app.BaseApp, apptesting.NewBlockHeader(app, blockTime), msg, helpers.TestChainID,
+			[]uint64{accNum}, []uint64{uint64(seq)}, true, true, priv)
+	}
+	apptesting.AdvanceBlocks(app, 5, blockTime.Add(5*time.Second), 5*time.Second)
+
+	apptesting.RequireExportImportEqual(t, app, apptesting.DefaultStoreSkipPrefixes())
+
+	// a key only in the imported app is reported for its store and decoded
+	newApp := apptesting.ExportAndImport(t, app, false, []string{})
+	newCtx := newApp.NewContext(false, tmproto.Header{})
+	extra := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	newApp.AccountKeeper.SetAccount(newCtx, newApp.AccountKeeper.NewAccountWithAddress(newCtx, extra))
+
+	diffs := apptesting.DiffAppStores(app, newApp, apptesting.DefaultStoreSkipPrefixes())
+	require.Len(t, diffs, 1)
+	require.Equal(t, authtypes.StoreKey, diffs[0].Store)
+	require.Empty(t, diffs[0].A.Key)
+	require.Equal(t, authtypes.AddressStoreKey(extra), diffs[0].B.Key)
+	require.NotEmpty(t, diffs[0].Decoded)
+
+	skip := apptesting.DefaultStoreSkipPrefixes()
+	skip[authtypes.StoreKey] = [][]byte{authtypes.AddressStoreKeyPrefix, authtypes.GlobalAccountNumberKey}
+	require.Empty(t, apptesting.DiffAppStores(app, newApp, skip))
+}
@@ -1,5 +1,20 @@
 package helpers
 
+import (
+	"github.com/cosmos/cosmos-sdk/client"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsign "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+const (
+	// DefaultGenTxGas is the gas limit of txs generated by GenTx in tests
+	DefaultGenTxGas = 1000000
+	// TestChainID is the chain-id used by the app test helpers
+	TestChainID = "jeongseup-test"
+)
+
 // EmptyAppOptions is a stub implementing AppOptions
 type EmptyAppOptions struct{}
 
@@ -7,3 +22,57 @@ type EmptyAppOptions struct{}
 func (ao EmptyAppOptions) Get(o string) interface{} {
 	return nil
 }
+
+// ref: https://github.com/cosmos/cosmos-sdk/blob/v0.45.4/simapp/helpers/test_helpers.go
+// GenTx generates a signed mock transaction.
+// simapp 과 달리 memo 를 랜덤으로 넣지 않아서 같은 입력이면 같은 tx bytes 가 나온다.
+func GenTx(gen client.TxConfig, msgs []sdk.Msg, feeAmt sdk.Coins, gas uint64, chainID string, accNums, accSeqs []uint64, priv ...cryptotypes.PrivKey) (sdk.Tx, error) {
+	sigs := make([]signing.SignatureV2, len(priv))
+
+	signMode := gen.SignModeHandler().DefaultMode()
+
+	// 1st round: set SignatureV2 with empty signatures, to set correct
+	// signer infos.
+	for i, p := range priv {
+		sigs[i] = signing.SignatureV2{
+			PubKey: p.PubKey(),
+			Data: &signing.SingleSignatureData{
+				SignMode: signMode,
+			},
+			Sequence: accSeqs[i],
+		}
+	}
+
+	tx := gen.NewTxBuilder()
+	if err := tx.SetMsgs(msgs...); err != nil {
+		return nil, err
+	}
+	if err := tx.SetSignatures(sigs...); err != nil {
+		return nil, err
+	}
+	tx.SetFeeAmount(feeAmt)
+	tx.SetGasLimit(gas)
+
+	// 2nd round: once all signer infos are set, every signer can sign.
+	for i, p := range priv {
+		signerData := authsign.SignerData{
+			ChainID:       chainID,
+			AccountNumber: accNums[i],
+			Sequence:      accSeqs[i],
+		}
+		signBytes, err := gen.SignModeHandler().GetSignBytes(signMode, signerData, tx.GetTx())
+		if err != nil {
+			return nil, err
+		}
+		sig, err := p.Sign(signBytes)
+		if err != nil {
+			return nil, err
+		}
+		sigs[i].Data.(*signing.SingleSignatureData).Signature = sig
+		if err := tx.SetSignatures(sigs...); err != nil {
+			return nil, err
+		}
+	}
+
+	return tx.GetTx(), nil
+}
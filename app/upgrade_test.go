@@ -0,0 +1,279 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/Jeongseup/jeongseupchain/app/apptesting"
+	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// appVersion is one release of the binary for the upgrade harness: the
+// upgrade registry it ships, and the modules, their stores and migrations it
+// adds on top of the app wiring.
+type appVersion struct {
+	upgrades   []jscapp.Upgrade
+	stores     []*sdk.KVStoreKey
+	modules    []module.AppModule
+	migrations func(module.Configurator) error
+}
+
+// versionedModule is a test module whose consensus version changes between
+// app versions. Outside of the order lists, which do not include it, the
+// module manager only calls Name and ConsensusVersion.
+type versionedModule struct {
+	module.AppModule
+
+	version uint64
+}
+
+const versionedModuleName = "versioned"
+
+func (versionedModule) Name() string { return versionedModuleName }
+
+func (m versionedModule) ConsensusVersion() uint64 { return m.version }
+
+// upgradeHarness runs a node on one db and home dir and swaps the binary,
+// i.e. the appVersion, in the middle of the chain like cosmovisor does.
+type upgradeHarness struct {
+	t         *testing.T
+	db        dbm.DB
+	homePath  string
+	app       *jscapp.JeongseupApp
+	blockTime time.Time
+}
+
+// newUpgradeHarness starts a chain with version and commits the genesis.
+func newUpgradeHarness(
+	t *testing.T, version appVersion, valSet *tmtypes.ValidatorSet,
+	genAccs []authtypes.GenesisAccount, balances ...banktypes.Balance,
+) *upgradeHarness {
+	h := &upgradeHarness{
+		t:         t,
+		db:        dbm.NewMemDB(),
+		homePath:  t.TempDir(),
+		blockTime: time.Unix(1_700_000_000, 0).UTC(),
+	}
+
+	h.open(version)
+	apptesting.InitChainWithValSet(t, h.app, valSet, genAccs, balances...)
+
+	return h
+}
+
+// open (re)starts the node with version on the same db and home dir. The
+// store loader and upgrade handlers come from the registry of version, the
+// app registry is not used.
+func (h *upgradeHarness) open(version appVersion) {
+	mountStores := func(bapp *baseapp.BaseApp) {
+		for _, key := range version.stores {
+			bapp.MountStore(key, sdk.StoreTypeIAVL)
+		}
+	}
+
+	app := jscapp.NewJeongseupApp(
+		log.NewNopLogger(), h.db, nil, false, map[int64]bool{},
+		h.homePath, 5, jscapp.MakeEncodingConfig(), helpers.EmptyAppOptions{}, mountStores,
+	)
+
+	for _, m := range version.modules {
+		app.ModuleManager().Modules[m.Name()] = m
+	}
+	if version.migrations != nil {
+		require.NoError(h.t, version.migrations(app.Configurator()))
+	}
+
+	app.SetupUpgrades(version.upgrades)
+	require.NoError(h.t, app.LoadLatestVersion())
+
+	h.app = app
+}
+
+// beginBlock begins the next block and returns its deliver context.
+func (h *upgradeHarness) beginBlock() sdk.Context {
+	ctx := apptesting.BeginNextBlock(h.app, h.blockTime)
+	h.blockTime = h.blockTime.Add(5 * time.Second)
+
+	return ctx
+}
+
+// nextBlocks runs n empty blocks.
+func (h *upgradeHarness) nextBlocks(n int) {
+	for i := 0; i < n; i++ {
+		h.beginBlock()
+		apptesting.EndBlockAndCommit(h.app)
+	}
+}
+
+// scheduleUpgrade schedules plan in the next block, as a passed software
+// upgrade proposal would.
+func (h *upgradeHarness) scheduleUpgrade(plan upgradetypes.Plan) {
+	ctx := h.beginBlock()
+	require.NoError(h.t, h.app.UpgradeKeeper.ScheduleUpgrade(ctx, plan))
+	apptesting.EndBlockAndCommit(h.app)
+}
+
+// runUntilHalt runs blocks until the binary halts at the upgrade height of
+// plan because it has no handler for it.
+func (h *upgradeHarness) runUntilHalt(plan upgradetypes.Plan) {
+	h.nextBlocks(int(plan.Height - h.app.LastBlockHeight() - 1))
+
+	require.PanicsWithValue(h.t, upgrade.BuildUpgradeNeededMsg(plan), func() {
+		h.beginBlock()
+	})
+	require.Equal(h.t, plan.Height-1, h.app.LastBlockHeight())
+
+	// the store loader of the next binary reads the upgrade info written at the halt
+	upgradeInfo, err := h.app.UpgradeKeeper.ReadUpgradeInfoFromDisk()
+	require.NoError(h.t, err)
+	require.Equal(h.t, plan.Name, upgradeInfo.Name)
+	require.Equal(h.t, plan.Height, upgradeInfo.Height)
+
+	// the halted block is run again by the next binary
+	h.blockTime = h.blockTime.Add(-5 * time.Second)
+}
+
+// queryCtx returns a context on the last committed state.
+func (h *upgradeHarness) queryCtx() sdk.Context {
+	return h.app.NewContext(true, tmproto.Header{Height: h.app.LastBlockHeight()})
+}
+
+func TestUpgradePath(t *testing.T) {
+	const (
+		v2UpgradeName    = "v2-test"
+		v2MaxValidators  = 150
+		v2UpgradeHeight  = 10
+		v2UpgradedMarker = "upgraded"
+		migratedMarker   = "migrated"
+	)
+
+	val := tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1)
+	_, addr := testKey("holder")
+	coins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100_000_000))
+
+	// v1 ships a test-only upgrade, the app registry itself is empty until a real release.
+	versionedKey := sdk.NewKVStoreKey(versionedModuleName)
+	v1 := appVersion{
+		upgrades: []jscapp.Upgrade{{
+			UpgradeName:          "v1-test",
+			CreateUpgradeHandler: jscapp.CreateDefaultUpgradeHandler,
+		}},
+		stores:  []*sdk.KVStoreKey{versionedKey},
+		modules: []module.AppModule{versionedModule{version: 1}},
+	}
+
+	// v2 는 module store 하나를 추가하고, versioned module 의 consensus version 을
+	// 올리고, upgrade handler 에서 staking param 을 바꾼다.
+	var h *upgradeHarness
+	var fromVersions module.VersionMap
+	var migrations int
+	v2StoreKey := sdk.NewKVStoreKey("v2test")
+	v2 := appVersion{
+		upgrades: append(append([]jscapp.Upgrade{}, v1.upgrades...), jscapp.Upgrade{
+			UpgradeName: v2UpgradeName,
+			CreateUpgradeHandler: func(mm *module.Manager, configurator module.Configurator) upgradetypes.UpgradeHandler {
+				return func(ctx sdk.Context, plan upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+					fromVersions = fromVM
+
+					params := h.app.StakingKeeper.GetParams(ctx)
+					params.MaxValidators = v2MaxValidators
+					h.app.StakingKeeper.SetParams(ctx, params)
+
+					ctx.KVStore(v2StoreKey).Set([]byte(v2UpgradedMarker), []byte(plan.Name))
+
+					return jscapp.CreateDefaultUpgradeHandler(mm, configurator)(ctx, plan, fromVM)
+				}
+			},
+			StoreUpgrades: storetypes.StoreUpgrades{Added: []string{v2StoreKey.Name()}},
+		}),
+		stores:  []*sdk.KVStoreKey{versionedKey, v2StoreKey},
+		modules: []module.AppModule{versionedModule{version: 2}},
+		migrations: func(configurator module.Configurator) error {
+			return configurator.RegisterMigration(versionedModuleName, 1, func(ctx sdk.Context) error {
+				migrations++
+				ctx.KVStore(versionedKey).Set([]byte(migratedMarker), []byte{1})
+				return nil
+			})
+		},
+	}
+
+	h = newUpgradeHarness(t, v1, tmtypes.NewValidatorSet([]*tmtypes.Validator{val}),
+		[]authtypes.GenesisAccount{authtypes.NewBaseAccountWithAddress(addr)},
+		banktypes.Balance{Address: addr.String(), Coins: coins},
+	)
+	h.nextBlocks(2)
+
+	require.Equal(t, uint64(1), h.app.UpgradeKeeper.GetModuleVersionMap(h.queryCtx())[versionedModuleName])
+
+	plan := upgradetypes.Plan{Name: v2UpgradeName, Height: v2UpgradeHeight}
+	h.scheduleUpgrade(plan)
+	h.runUntilHalt(plan)
+
+	v1Versions := h.app.UpgradeKeeper.GetModuleVersionMap(h.queryCtx())
+	v1StakingParams := h.app.StakingKeeper.GetParams(h.queryCtx())
+	require.NotEqual(t, uint32(v2MaxValidators), v1StakingParams.MaxValidators)
+
+	// restart with the v2 binary, which runs the upgrade at the halted height
+	h.open(v2)
+	require.Equal(t, plan.Height-1, h.app.LastBlockHeight())
+	require.Zero(t, migrations)
+	h.nextBlocks(1)
+	require.Equal(t, plan.Height, h.app.LastBlockHeight())
+
+	ctx := h.queryCtx()
+	require.Equal(t, v1Versions, fromVersions)
+	require.Equal(t, 1, migrations)
+	require.Equal(t, []byte{1}, ctx.KVStore(versionedKey).Get([]byte(migratedMarker)))
+
+	storedVersions := h.app.UpgradeKeeper.GetModuleVersionMap(ctx)
+	require.Equal(t, uint64(2), storedVersions[versionedModuleName])
+	require.Equal(t, h.app.ModuleManager().GetVersionMap(), storedVersions)
+	require.Equal(t, plan.Height, h.app.UpgradeKeeper.GetDoneHeight(ctx, v2UpgradeName))
+	_, found := h.app.UpgradeKeeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	v2StakingParams := v1StakingParams
+	v2StakingParams.MaxValidators = v2MaxValidators
+	require.Equal(t, v2StakingParams, h.app.StakingKeeper.GetParams(ctx))
+	require.Equal(t, coins, h.app.BankKeeper.GetAllBalances(ctx, addr))
+	require.Equal(t, []byte(v2UpgradeName), ctx.KVStore(v2StoreKey).Get([]byte(v2UpgradedMarker)))
+
+	// 이후 재시작에서는 upgrade 없이 추가된 store 를 그대로 load 한다.
+	h.nextBlocks(2)
+	h.open(v2)
+	h.nextBlocks(1)
+	require.Equal(t, plan.Height+3, h.app.LastBlockHeight())
+	require.Equal(t, 1, migrations)
+
+	ctx = h.queryCtx()
+	require.Equal(t, []byte(v2UpgradeName), ctx.KVStore(v2StoreKey).Get([]byte(v2UpgradedMarker)))
+	require.Equal(t, v2StakingParams, h.app.StakingKeeper.GetParams(ctx))
+	require.Equal(t, coins, h.app.BankKeeper.GetAllBalances(ctx, addr))
+	require.Equal(t, storedVersions, h.app.UpgradeKeeper.GetModuleVersionMap(ctx))
+
+	// the v1 binary has no handler for the applied upgrade and refuses to run
+	h.open(v1)
+	name, height := h.app.UpgradeKeeper.GetLastCompletedUpgrade(h.queryCtx())
+	require.Equal(t, v2UpgradeName, name)
+	require.Equal(t, plan.Height, height)
+	require.False(t, h.app.UpgradeKeeper.HasHandler(name))
+	// v0.45 는 panic message 에 consensus version param 을 쓰는데 baseapp 이 저장하지
+	// 않으므로 message 는 확인하지 않는다.
+	require.Panics(t, func() { h.beginBlock() })
+}
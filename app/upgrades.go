@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+)
+
+// Upgrade defines a named software upgrade that this binary knows how to run.
+// Every release which changes state adds an entry to Upgrades with the same
+// name as the governance SoftwareUpgradeProposal plan.
+type Upgrade struct {
+	// UpgradeName must match the name of the on-chain upgrade plan.
+	UpgradeName string
+
+	// CreateUpgradeHandler returns the handler executed at the upgrade height.
+	CreateUpgradeHandler func(*module.Manager, module.Configurator) upgradetypes.UpgradeHandler
+
+	// StoreUpgrades lists the store keys added, renamed and deleted by the upgrade.
+	StoreUpgrades storetypes.StoreUpgrades
+}
+
+// Upgrades is the registry of named upgrades, in release order. It is empty
+// until the first release which is proposed on-chain.
+// 새 release를 만들 때 여기에 upgrade를 추가하면 됨, e.g.
+//
+//	{
+//		UpgradeName:          "v2",
+//		CreateUpgradeHandler: CreateDefaultUpgradeHandler,
+//		StoreUpgrades:        storetypes.StoreUpgrades{Added: []string{...}},
+//	},
+var Upgrades = []Upgrade{}
+
+// CreateDefaultUpgradeHandler returns an upgrade handler which only runs the
+// registered module migrations.
+func CreateDefaultUpgradeHandler(mm *module.Manager, configurator module.Configurator) upgradetypes.UpgradeHandler {
+	return func(ctx sdk.Context, plan upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		ctx.Logger().Info("running module migrations", "upgrade", plan.Name)
+		return mm.RunMigrations(ctx, configurator, fromVM)
+	}
+}
+
+// setupUpgradeHandlers registers the handler of every upgrade in upgrades.
+func (app *JeongseupApp) setupUpgradeHandlers(upgrades []Upgrade) {
+	for _, u := range upgrades {
+		app.UpgradeKeeper.SetUpgradeHandler(u.UpgradeName, u.CreateUpgradeHandler(app.mm, app.configurator))
+	}
+}
+
+// setupUpgradeStoreLoaders sets the store loader of the pending upgrade in
+// upgrades, if the upgrade module wrote one to disk when it halted the
+// previous binary.
+func (app *JeongseupApp) setupUpgradeStoreLoaders(upgrades []Upgrade) {
+	upgradeInfo, err := app.UpgradeKeeper.ReadUpgradeInfoFromDisk()
+	if err != nil {
+		panic(fmt.Sprintf("failed to read upgrade info from disk: %s", err))
+	}
+
+	if app.UpgradeKeeper.IsSkipHeight(upgradeInfo.Height) {
+		return
+	}
+
+	for _, u := range upgrades {
+		if upgradeInfo.Name != u.UpgradeName {
+			continue
+		}
+
+		storeUpgrades := u.StoreUpgrades
+		app.SetStoreLoader(upgradetypes.UpgradeStoreLoader(upgradeInfo.Height, &storeUpgrades))
+	}
+}
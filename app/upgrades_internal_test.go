@@ -0,0 +1,27 @@
+package app
+
+import (
+	"github.com/cosmos/cosmos-sdk/types/module"
+)
+
+// upgrade test 는 apptesting 을 쓰기 위해 app_test package 에 있으므로, 여기서
+// module manager 와 upgrade setup 을 test 에서만 노출한다.
+
+// ModuleManager returns the module manager of app.
+func (app *JeongseupApp) ModuleManager() *module.Manager {
+	return app.mm
+}
+
+// Configurator returns the configurator the modules registered their
+// services and migrations on.
+func (app *JeongseupApp) Configurator() module.Configurator {
+	return app.configurator
+}
+
+// SetupUpgrades registers the handlers and the store loader of upgrades, in
+// place of the Upgrades registry. It has to be called before the app is
+// loaded.
+func (app *JeongseupApp) SetupUpgrades(upgrades []Upgrade) {
+	app.setupUpgradeHandlers(upgrades)
+	app.setupUpgradeStoreLoaders(upgrades)
+}
@@ -0,0 +1,71 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/Jeongseup/jeongseupchain/app/apptesting"
+	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+func TestCreateVestingAccountMsg(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+	from := sdk.AccAddress(priv.PubKey().Address())
+	toPriv := secp256k1.GenPrivKey()
+	to := sdk.AccAddress(toPriv.PubKey().Address())
+
+	initCoins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100_000_000))
+	valSet := tmtypes.NewValidatorSet([]*tmtypes.Validator{
+		tmtypes.NewValidator(ed25519.GenPrivKey().PubKey(), 1),
+	})
+	app := apptesting.SetupWithGenesisValSet(t, valSet,
+		[]authtypes.GenesisAccount{authtypes.NewBaseAccount(from, priv.PubKey(), 0, 0)},
+		banktypes.Balance{Address: from.String(), Coins: initCoins})
+
+	ctx := app.NewContext(true, tmproto.Header{})
+	accNum := app.AccountKeeper.GetAccount(ctx, from).GetAccountNumber()
+
+	// runtime 에 MsgCreateVestingAccount 로 continuous vesting account 만들기
+	blockTime := time.Unix(1_700_000_000, 0).UTC()
+	endTime := blockTime.Add(time.Hour).Unix()
+	vestingCoins := sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 1000))
+	msg := vestingtypes.NewMsgCreateVestingAccount(from, to, vestingCoins, endTime, false)
+
+	header := apptesting.NewBlockHeader(app, blockTime)
+	_, _, err := apptesting.SignCheckDeliver(t, jscapp.MakeEncodingConfig().TxConfig, app.BaseApp, header, []sdk.Msg{msg},
+		helpers.TestChainID, []uint64{accNum}, []uint64{0}, true, true, priv)
+	require.NoError(t, err)
+
+	ctx = app.NewContext(true, tmproto.Header{Height: app.LastBlockHeight()})
+	acc, ok := app.AccountKeeper.GetAccount(ctx, to).(*vestingtypes.ContinuousVestingAccount)
+	require.True(t, ok, "expected continuous vesting account, got %T", app.AccountKeeper.GetAccount(ctx, to))
+	require.Equal(t, vestingCoins, acc.GetOriginalVesting())
+	require.Equal(t, blockTime.Unix(), acc.GetStartTime())
+	require.Equal(t, endTime, acc.GetEndTime())
+
+	// nothing vested at the start, half of it after 30 minutes
+	require.Equal(t, vestingCoins, acc.LockedCoins(blockTime))
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 500)), acc.LockedCoins(blockTime.Add(30*time.Minute)))
+	require.True(t, acc.LockedCoins(time.Unix(endTime, 0)).IsZero())
+	require.Equal(t, vestingCoins, app.BankKeeper.GetAllBalances(ctx, to))
+	apptesting.CheckBalance(t, app, from, initCoins.Sub(vestingCoins))
+
+	// locked coins 는 보낼 수 없다.
+	header = apptesting.NewBlockHeader(app, blockTime.Add(5*time.Second))
+	_, _, err = apptesting.SignCheckDeliver(t, jscapp.MakeEncodingConfig().TxConfig, app.BaseApp, header,
+		[]sdk.Msg{banktypes.NewMsgSend(to, from, vestingCoins)},
+		helpers.TestChainID, []uint64{acc.GetAccountNumber()}, []uint64{0}, false, false, toPriv)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+}
@@ -2,9 +2,8 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"io"
-
-	stdlog "log"
 	"path/filepath"
 
 	jscapp "github.com/Jeongseup/jeongseupchain/app"
@@ -15,6 +14,7 @@ import (
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
 	"github.com/cosmos/cosmos-sdk/snapshots"
 	"github.com/cosmos/cosmos-sdk/store"
+	"github.com/cosmos/cosmos-sdk/store/rootmulti"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cast"
 	"github.com/tendermint/tendermint/libs/log"
@@ -85,24 +85,28 @@ func (ac appCreator) newApp(
 
 }
 
-func (ac appCreator) appExport(
+// loadExportApp creates the app for an export at the given height, -1 meaning
+// the latest height.
+func (ac appCreator) loadExportApp(
 	logger log.Logger,
 	db dbm.DB,
 	traceStore io.Writer,
 	height int64,
-	forZeroHeight bool,
-	jailAllowedAddrs []string,
 	appOpts servertypes.AppOptions,
-) (servertypes.ExportedApp, error) {
-	stdlog.Println("be called exporter? ")
+) (*jscapp.JeongseupApp, error) {
 	homePath, ok := appOpts.Get(flags.FlagHome).(string)
 	if !ok || homePath == "" {
-		return servertypes.ExportedApp{}, errors.New("application home is not set")
+		return nil, errors.New("application home is not set")
+	}
+
+	// 앱을 만들기 전에 db 에서 바로 최신 높이를 읽는다.
+	latestHeight := rootmulti.NewStore(db).LastCommitID().Version
+	if height != -1 && (height <= 0 || height > latestHeight) {
+		return nil, fmt.Errorf("cannot export at height %d: height must be between 1 and the latest height %d", height, latestHeight)
 	}
 
 	var loadLatest bool
 	if height == -1 {
-		stdlog.Println("load latest!")
 		loadLatest = true
 	}
 
@@ -120,9 +124,12 @@ func (ac appCreator) appExport(
 
 	if height != -1 {
 		if err := jeongseupApp.LoadHeight(height); err != nil {
-			return servertypes.ExportedApp{}, err
+			return nil, fmt.Errorf(
+				"failed to load state at height %d (latest height %d); the height may have been pruned, see the pruning settings in app.toml: %w",
+				height, latestHeight, err,
+			)
 		}
 	}
 
-	return jeongseupApp.ExportAppStateAndValidators(forZeroHeight, jailAllowedAddrs)
+	return jeongseupApp, nil
 }
@@ -22,3 +22,20 @@ func TestInitCmd(t *testing.T) {
 
 	require.NoError(t, svrcmd.Execute(rootCmd, jscapp.DefaultNodeHome))
 }
+
+func TestRootCmdServerCommands(t *testing.T) {
+	rootCmd, _ := jsccmd.NewRootCmd()
+
+	registered := make(map[string]int)
+	for _, c := range rootCmd.Commands() {
+		registered[c.Name()]++
+	}
+
+	for _, name := range []string{"start", "tendermint", "export", "version", "rollback"} {
+		require.Equal(t, 1, registered[name], name)
+	}
+
+	exportCmd, _, err := rootCmd.Find([]string{"export"})
+	require.NoError(t, err)
+	require.NotNil(t, exportCmd.Flags().Lookup("modules"))
+}
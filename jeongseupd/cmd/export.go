@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagExportModules  = "modules"
+	flagOutputDocument = "output-document"
+	flagCompact        = "compact"
+	flagTraceStore     = "trace-store"
+)
+
+// ExportCmd replaces the server export command. It streams the app state
+// module by module to stdout or --output-document and can export a subset
+// of modules.
+func ExportCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export state to JSON",
+		Long: `Export state to JSON.
+
+The app state is written one module at a time, so the whole genesis is never
+held in memory. Use --modules to export a subset of modules and --compact to
+skip indentation. With --height the state of an older height is exported; this
+fails if that height was pruned.
+
+The genesis is written to a temporary file first and only printed, or moved to
+--output-document, once the export succeeded.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+
+			homeDir, _ := cmd.Flags().GetString(flags.FlagHome)
+			config.SetRoot(homeDir)
+
+			if _, err := os.Stat(config.GenesisFile()); os.IsNotExist(err) {
+				return err
+			}
+
+			height, _ := cmd.Flags().GetInt64(server.FlagHeight)
+			forZeroHeight, _ := cmd.Flags().GetBool(server.FlagForZeroHeight)
+			jailAllowedAddrs, _ := cmd.Flags().GetStringSlice(server.FlagJailAllowedAddrs)
+			modules, _ := cmd.Flags().GetStringSlice(flagExportModules)
+			outputDocument, _ := cmd.Flags().GetString(flagOutputDocument)
+			compact, _ := cmd.Flags().GetBool(flagCompact)
+
+			doc, err := tmtypes.GenesisDocFromFile(config.GenesisFile())
+			if err != nil {
+				return err
+			}
+
+			db, err := sdk.NewLevelDB("application", filepath.Join(config.RootDir, "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			traceWriterFile, _ := cmd.Flags().GetString(flagTraceStore)
+			traceWriter, err := openTraceWriter(traceWriterFile)
+			if err != nil {
+				return err
+			}
+			if traceWriter != nil {
+				defer traceWriter.Close()
+			}
+
+			ac := appCreator{encCfg: jscapp.MakeEncodingConfig()}
+			jeongseupApp, err := ac.loadExportApp(serverCtx.Logger, db, traceWriter, height, serverCtx.Viper)
+			if err != nil {
+				return fmt.Errorf("error exporting state: %w", err)
+			}
+
+			opts := jscapp.ExportOptions{
+				ForZeroHeight:    forZeroHeight,
+				JailAllowedAddrs: jailAllowedAddrs,
+				Modules:          modules,
+			}
+			if !compact {
+				opts.Indent = "  "
+				opts.Prefix = "  "
+			}
+
+			// export 가 성공한 뒤에만 결과가 나가도록 temp file 에 먼저 쓴다.
+			// 실패하면 stdout 이나 --output-document 에 잘린 JSON 이 남지 않는다.
+			dir := os.TempDir()
+			if outputDocument != "" {
+				dir = filepath.Dir(outputDocument)
+			}
+
+			f, err := os.CreateTemp(dir, "jeongseupd-export-*.json")
+			if err != nil {
+				return err
+			}
+			defer os.Remove(f.Name())
+			defer f.Close()
+
+			if err := writeExportedGenesis(f, jeongseupApp, doc, opts); err != nil {
+				return err
+			}
+
+			if outputDocument != "" {
+				if err := f.Chmod(0o644); err != nil {
+					return err
+				}
+				if err := f.Close(); err != nil {
+					return err
+				}
+				return os.Rename(f.Name(), outputDocument)
+			}
+
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			_, err = io.Copy(cmd.OutOrStdout(), f)
+			return err
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().Int64(server.FlagHeight, -1, "Export state from a particular height (-1 means latest height)")
+	cmd.Flags().Bool(server.FlagForZeroHeight, false, "Export state to start at height zero (perform preproccessing)")
+	cmd.Flags().StringSlice(server.FlagJailAllowedAddrs, []string{}, "Comma-separated list of operator addresses of jailed validators to unjail")
+	cmd.Flags().StringSlice(flagExportModules, []string{}, "Comma-separated list of modules to export (default all modules)")
+	cmd.Flags().String(flagOutputDocument, "", "Write the exported genesis to the given file instead of stdout")
+	cmd.Flags().Bool(flagCompact, false, "Write compact JSON without indentation")
+	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
+
+	return cmd
+}
+
+// writeExportedGenesis writes the genesis document with the app state first,
+// because validators and the initial height are only known after the app
+// state export (e.g. zero height preparation changes the validator set).
+func writeExportedGenesis(w io.Writer, jeongseupApp *jscapp.JeongseupApp, doc *tmtypes.GenesisDoc, opts jscapp.ExportOptions) error {
+	// 모듈 확인, zero height 준비, validator set 은 아무것도 쓰기 전에 끝낸다.
+	export, err := jeongseupApp.PrepareExport(opts)
+	if err != nil {
+		return fmt.Errorf("error exporting state: %w", err)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	open, colon := `{"app_state":`, ""
+	if opts.Indent != "" {
+		open, colon = "{\n"+opts.Prefix+`"app_state"`, ": "
+	}
+	if _, err := bw.WriteString(open + colon); err != nil {
+		return err
+	}
+
+	if err := export.WriteAppState(bw); err != nil {
+		return fmt.Errorf("error exporting state: %w", err)
+	}
+
+	setExportedGenesisDoc(doc, export.ExportedApp)
+
+	// NOTE: Tendermint uses a custom JSON decoder for GenesisDoc
+	// (except for stuff inside AppState).
+	doc.AppState = nil
+	var encoded []byte
+	if opts.Indent != "" {
+		encoded, err = tmjson.MarshalIndent(doc, "", opts.Indent)
+	} else {
+		encoded, err = tmjson.Marshal(doc)
+	}
+	if err != nil {
+		return err
+	}
+
+	// encoded 는 "{" 로 시작하므로 app_state 뒤에 나머지 필드를 이어 붙인다.
+	sep := ","
+	if opts.Indent != "" {
+		sep = ",\n"
+		encoded = encoded[2:] // "{\n"
+	} else {
+		encoded = encoded[1:] // "{"
+	}
+
+	if _, err := bw.WriteString(sep); err != nil {
+		return err
+	}
+	if _, err := bw.Write(encoded); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString("\n"); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// setExportedGenesisDoc copies the exported validators, height and consensus
+// params into doc, the same way the server export command does.
+func setExportedGenesisDoc(doc *tmtypes.GenesisDoc, exported servertypes.ExportedApp) {
+	doc.Validators = exported.Validators
+	doc.InitialHeight = exported.Height
+	doc.ConsensusParams = &tmproto.ConsensusParams{
+		Block: tmproto.BlockParams{
+			MaxBytes:   exported.ConsensusParams.Block.MaxBytes,
+			MaxGas:     exported.ConsensusParams.Block.MaxGas,
+			TimeIotaMs: doc.ConsensusParams.Block.TimeIotaMs,
+		},
+		Evidence: tmproto.EvidenceParams{
+			MaxAgeNumBlocks: exported.ConsensusParams.Evidence.MaxAgeNumBlocks,
+			MaxAgeDuration:  exported.ConsensusParams.Evidence.MaxAgeDuration,
+			MaxBytes:        exported.ConsensusParams.Evidence.MaxBytes,
+		},
+		Validator: tmproto.ValidatorParams{
+			PubKeyTypes: exported.ConsensusParams.Validator.PubKeyTypes,
+		},
+	}
+}
+
+// openTraceWriter opens the KVStore trace file like the server start and
+// export commands do. No file is opened for an empty path.
+func openTraceWriter(traceWriterFile string) (io.WriteCloser, error) {
+	if traceWriterFile == "" {
+		return nil, nil
+	}
+
+	return os.OpenFile(traceWriterFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o666)
+}
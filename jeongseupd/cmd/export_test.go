@@ -0,0 +1,175 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	jsccmd "github.com/Jeongseup/jeongseupchain/jeongseupd/cmd"
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	genutiltest "github.com/cosmos/cosmos-sdk/x/genutil/client/testutil"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// setupExportHome creates a node home whose application db has committed
+// the given number of blocks with pruning everything.
+func setupExportHome(t *testing.T, blocks int64) string {
+	home := t.TempDir()
+	cfg, err := genutiltest.CreateDefaultTendermintConfig(home)
+	require.NoError(t, err)
+
+	encCfg := jscapp.MakeEncodingConfig()
+	require.NoError(t, genutiltest.ExecInitCmd(jscapp.ModuleBasics, home, encCfg.Marshaler))
+
+	genDoc, err := tmtypes.GenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+
+	db, err := dbm.NewGoLevelDB("application", filepath.Join(home, "data"))
+	require.NoError(t, err)
+
+	app := jscapp.NewJeongseupApp(
+		log.NewNopLogger(), db, nil, true, map[int64]bool{}, home, 0, encCfg,
+		helpers.EmptyAppOptions{}, baseapp.SetPruning(storetypes.PruneEverything),
+	)
+
+	app.InitChain(abci.RequestInitChain{
+		ChainId:         genDoc.ChainID,
+		ConsensusParams: tmtypes.TM2PB.ConsensusParams(genDoc.ConsensusParams),
+		Validators:      []abci.ValidatorUpdate{},
+		AppStateBytes:   genDoc.AppState,
+	})
+
+	for height := int64(1); height <= blocks; height++ {
+		header := tmproto.Header{ChainID: genDoc.ChainID, Height: height}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+	}
+
+	require.NoError(t, db.Close())
+	return home
+}
+
+func execExportCmd(home string, args ...string) (string, error) {
+	rootCmd, _ := jsccmd.NewRootCmd()
+	out := new(bytes.Buffer)
+	rootCmd.SetOut(out)
+	rootCmd.SetArgs(append([]string{"export", fmt.Sprintf("--home=%s", home)}, args...))
+
+	err := svrcmd.Execute(rootCmd, home)
+	return out.String(), err
+}
+
+func TestExportCmd(t *testing.T) {
+	home := setupExportHome(t, 25)
+
+	out, err := execExportCmd(home)
+	require.NoError(t, err)
+
+	genDoc, err := tmtypes.GenesisDocFromJSON([]byte(out))
+	require.NoError(t, err)
+	require.Equal(t, int64(26), genDoc.InitialHeight)
+
+	var appState map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(genDoc.AppState, &appState))
+	require.Len(t, appState, len(jscapp.ModuleBasics))
+	require.NoError(t, jscapp.ModuleBasics.ValidateGenesis(
+		jscapp.MakeEncodingConfig().Marshaler, jscapp.MakeEncodingConfig().TxConfig, appState))
+
+	// subset of modules, compact, written to a file
+	outputDocument := filepath.Join(t.TempDir(), "export.json")
+	_, err = execExportCmd(home,
+		fmt.Sprintf("--modules=%s,%s", banktypes.ModuleName, stakingtypes.ModuleName),
+		"--compact",
+		fmt.Sprintf("--output-document=%s", outputDocument),
+	)
+	require.NoError(t, err)
+
+	bz, err := os.ReadFile(outputDocument)
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(string(bz), "\n"))
+
+	genDoc, err = tmtypes.GenesisDocFromJSON(bz)
+	require.NoError(t, err)
+
+	appState = nil
+	require.NoError(t, json.Unmarshal(genDoc.AppState, &appState))
+	require.Len(t, appState, 2)
+	require.Contains(t, appState, banktypes.ModuleName)
+	require.Contains(t, appState, stakingtypes.ModuleName)
+
+	// a failed export leaves the earlier document and no temp file behind
+	_, err = execExportCmd(home, "--modules=unknown", fmt.Sprintf("--output-document=%s", outputDocument))
+	require.Error(t, err)
+	after, err := os.ReadFile(outputDocument)
+	require.NoError(t, err)
+	require.Equal(t, bz, after)
+	entries, err := os.ReadDir(filepath.Dir(outputDocument))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	failedDocument := filepath.Join(t.TempDir(), "failed.json")
+	_, err = execExportCmd(home, "--modules=unknown", fmt.Sprintf("--output-document=%s", failedDocument))
+	require.Error(t, err)
+	require.NoFileExists(t, failedDocument)
+}
+
+func TestExportCmdFailureWritesNothing(t *testing.T) {
+	home := setupExportHome(t, 5)
+
+	for _, args := range [][]string{
+		{"--modules=unknown"},
+		{"--for-zero-height", "--jail-allowed-addrs=invalid"},
+	} {
+		out, err := execExportCmd(home, args...)
+		require.Error(t, err, args)
+		// only the usage is printed, no part of the genesis
+		require.NotContains(t, out, "{", args)
+	}
+}
+
+func TestExportCmdTraceStore(t *testing.T) {
+	home := setupExportHome(t, 5)
+	traceFile := filepath.Join(t.TempDir(), "trace.log")
+
+	_, err := execExportCmd(home, fmt.Sprintf("--trace-store=%s", traceFile))
+	require.NoError(t, err)
+
+	trace, err := os.ReadFile(traceFile)
+	require.NoError(t, err)
+	require.NotEmpty(t, trace)
+}
+
+func TestExportCmdHeight(t *testing.T) {
+	home := setupExportHome(t, 25)
+
+	// recent heights are kept by pruning everything
+	out, err := execExportCmd(home, "--height=24")
+	require.NoError(t, err)
+
+	genDoc, err := tmtypes.GenesisDocFromJSON([]byte(out))
+	require.NoError(t, err)
+	require.Equal(t, int64(25), genDoc.InitialHeight)
+
+	_, err = execExportCmd(home, "--height=2")
+	require.ErrorContains(t, err, "pruned")
+
+	_, err = execExportCmd(home, "--height=100")
+	require.ErrorContains(t, err, "latest height 25")
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
@@ -24,6 +26,11 @@ const (
 	flagVestingStart = "vesting-start-time"
 	flagVestingEnd   = "vesting-end-time"
 	flagVestingAmt   = "vesting-amount"
+
+	flagVestingPeriods    = "vesting-periods"
+	flagPermanentLocked   = "permanent-locked"
+	flagModuleAccount     = "module-account"
+	flagModulePermissions = "module-permissions"
 )
 
 // AddGenesisAccountCmd returns add-genesis-account cobra Command.
@@ -35,6 +42,14 @@ func AddGenesisAccountCmd(defaultNodeHome string) *cobra.Command {
 the account address or key name and a list of initial coins. If a key name is given,
 the address will be looked up in the local Keybase. The list of initial tokens must
 contain valid denominations. Accounts may optionally be supplied with vesting parameters.
+
+Periodic vesting accounts read their schedule from a JSON file given with --vesting-periods:
+
+	{"start_time": 1672531200, "periods": [{"length_seconds": 7776000, "coins": "250stake"}, ...]}
+
+Permanent locked accounts (--permanent-locked) lock --vesting-amount, or all coins when it is
+not set, forever. With --module-account the first argument is a module name instead of an
+address, and --module-permissions (minter,burner,staking) sets the account permissions.
 `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -44,28 +59,6 @@ contain valid denominations. Accounts may optionally be supplied with vesting pa
 
 			config.SetRoot(clientCtx.HomeDir)
 
-			var kr keyring.Keyring
-			addr, err := sdk.AccAddressFromBech32(args[0])
-			if err != nil {
-				inBuf := bufio.NewReader(cmd.InOrStdin())
-				keyringBackend, _ := cmd.Flags().GetString(flags.FlagKeyringBackend)
-				if keyringBackend != "" && clientCtx.Keyring == nil {
-					var err error
-					kr, err = keyring.New(sdk.KeyringServiceName(), keyringBackend, clientCtx.HomeDir, inBuf)
-					if err != nil {
-						return err
-					}
-				} else {
-					kr = clientCtx.Keyring
-				}
-
-				info, err := kr.Key(args[0])
-				if err != nil {
-					return fmt.Errorf("failed to get address from Keyring: %w", err)
-				}
-				addr = info.GetAddress()
-			}
-
 			coins, err := sdk.ParseCoinsNormalized(args[1])
 			if err != nil {
 				return fmt.Errorf("failed to parse coins: %w", err)
@@ -74,43 +67,81 @@ contain valid denominations. Accounts may optionally be supplied with vesting pa
 			vestingStart, _ := cmd.Flags().GetInt64(flagVestingStart)
 			vestingEnd, _ := cmd.Flags().GetInt64(flagVestingEnd)
 			vestingAmtStr, _ := cmd.Flags().GetString(flagVestingAmt)
+			vestingPeriodsFile, _ := cmd.Flags().GetString(flagVestingPeriods)
+			permanentLocked, _ := cmd.Flags().GetBool(flagPermanentLocked)
+			moduleAccount, _ := cmd.Flags().GetBool(flagModuleAccount)
+			modulePerms, _ := cmd.Flags().GetStringSlice(flagModulePermissions)
 
 			vestingAmt, err := sdk.ParseCoinsNormalized(vestingAmtStr)
 			if err != nil {
 				return fmt.Errorf("failed to parse vesting amount: %w", err)
 			}
 
-			// create concrete account type based on input parameters
-			var genAccount authtypes.GenesisAccount
-			// cmd 파라미터로 받은 데이터
-			balances := banktypes.Balance{Address: addr.String(), Coins: coins.Sort()}
-			baseAccount := authtypes.NewBaseAccount(addr, nil, 0, 0)
+			if !moduleAccount && len(modulePerms) > 0 {
+				return fmt.Errorf("--%s requires --%s", flagModulePermissions, flagModuleAccount)
+			}
 
-			if !vestingAmt.IsZero() {
-				baseVestingAccount := authvesting.NewBaseVestingAccount(baseAccount, vestingAmt.Sort(), vestingEnd)
+			// create concrete account type based on input parameters
+			var (
+				genAccount authtypes.GenesisAccount
+				balances   banktypes.Balance
+			)
+
+			if moduleAccount {
+				if !vestingAmt.IsZero() || vestingStart != 0 || vestingEnd != 0 || vestingPeriodsFile != "" || permanentLocked {
+					return errors.New("module accounts cannot have vesting parameters")
+				}
 
-				if (balances.Coins.IsZero() && !baseVestingAccount.OriginalVesting.IsZero()) ||
-					baseVestingAccount.OriginalVesting.IsAnyGT(balances.Coins) {
-					return errors.New("vesting amount cannot be greater than total amount")
+				genAccount, balances, err = newModuleGenesisAccount(args[0], modulePerms, coins)
+				if err != nil {
+					return err
+				}
+			} else {
+				addr, err := genesisAccountAddress(cmd, clientCtx, args[0])
+				if err != nil {
+					return err
 				}
 
 				switch {
-				case vestingStart != 0 && vestingEnd != 0:
-					genAccount = authvesting.NewContinuousVestingAccountRaw(baseVestingAccount, vestingStart)
+				case vestingPeriodsFile != "":
+					if !vestingAmt.IsZero() || vestingEnd != 0 || permanentLocked {
+						return fmt.Errorf("--%s cannot be combined with --%s, --%s or --%s",
+							flagVestingPeriods, flagVestingAmt, flagVestingEnd, flagPermanentLocked)
+					}
 
-				case vestingEnd != 0:
-					genAccount = authvesting.NewDelayedVestingAccountRaw(baseVestingAccount)
+					startTime, periods, err := readVestingPeriods(vestingPeriodsFile)
+					if err != nil {
+						return err
+					}
+					if startTime == 0 {
+						startTime = vestingStart
+					}
+
+					genAccount, balances, err = newPeriodicVestingGenesisAccount(addr, coins, startTime, periods)
+					if err != nil {
+						return err
+					}
+
+				case permanentLocked:
+					if vestingStart != 0 || vestingEnd != 0 {
+						return fmt.Errorf("--%s cannot be combined with --%s or --%s",
+							flagPermanentLocked, flagVestingStart, flagVestingEnd)
+					}
+
+					genAccount, balances, err = newPermanentLockedGenesisAccount(addr, coins, vestingAmt)
+					if err != nil {
+						return err
+					}
 
 				default:
-					return errors.New("invalid vesting parameters; must supply start and end time or end time")
+					genAccount, balances, err = newGenesisAccount(addr, coins, vestingAmt, vestingStart, vestingEnd)
+					if err != nil {
+						return err
+					}
 				}
-			} else {
-				genAccount = baseAccount
 			}
 
-			if err := genAccount.Validate(); err != nil {
-				return fmt.Errorf("failed to validate new genesis account: %w", err)
-			}
+			addr := genAccount.GetAddress()
 
 			genFile := config.GenesisFile()
 			appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(genFile)
@@ -174,7 +205,227 @@ contain valid denominations. Accounts may optionally be supplied with vesting pa
 	cmd.Flags().String(flagVestingAmt, "", "amount of coins for vesting accounts")
 	cmd.Flags().Int64(flagVestingStart, 0, "schedule start time (unix epoch) for vesting accounts")
 	cmd.Flags().Int64(flagVestingEnd, 0, "schedule end time (unix epoch) for vesting accounts")
+	cmd.Flags().String(flagVestingPeriods, "", "path to a JSON file with the start time and periods of a periodic vesting account")
+	cmd.Flags().Bool(flagPermanentLocked, false, "create a permanent locked account which locks --vesting-amount (or all coins) forever")
+	cmd.Flags().Bool(flagModuleAccount, false, "treat the first argument as a module name and create a module account")
+	cmd.Flags().StringSlice(flagModulePermissions, []string{}, "permissions of the module account (minter,burner,staking)")
 	flags.AddQueryFlagsToCmd(cmd)
 
 	return cmd
 }
+
+// newGenesisAccount creates the concrete genesis account and its balance from
+// the given coins and vesting parameters, and validates the account.
+func newGenesisAccount(
+	addr sdk.AccAddress, coins, vestingAmt sdk.Coins, vestingStart, vestingEnd int64,
+) (authtypes.GenesisAccount, banktypes.Balance, error) {
+	var genAccount authtypes.GenesisAccount
+	// cmd 파라미터로 받은 데이터
+	balances := banktypes.Balance{Address: addr.String(), Coins: coins.Sort()}
+	baseAccount := authtypes.NewBaseAccount(addr, nil, 0, 0)
+
+	if !vestingAmt.IsZero() {
+		baseVestingAccount := authvesting.NewBaseVestingAccount(baseAccount, vestingAmt.Sort(), vestingEnd)
+
+		if (balances.Coins.IsZero() && !baseVestingAccount.OriginalVesting.IsZero()) ||
+			!baseVestingAccount.OriginalVesting.IsAllLTE(balances.Coins) {
+			return nil, banktypes.Balance{}, errors.New("vesting amount cannot be greater than total amount")
+		}
+
+		switch {
+		case vestingStart != 0 && vestingEnd != 0:
+			genAccount = authvesting.NewContinuousVestingAccountRaw(baseVestingAccount, vestingStart)
+
+		case vestingEnd != 0:
+			genAccount = authvesting.NewDelayedVestingAccountRaw(baseVestingAccount)
+
+		default:
+			return nil, banktypes.Balance{}, errors.New("invalid vesting parameters; must supply start and end time or end time")
+		}
+	} else {
+		genAccount = baseAccount
+	}
+
+	if err := genAccount.Validate(); err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to validate new genesis account: %w", err)
+	}
+
+	return genAccount, balances, nil
+}
+
+// genesisAccountAddress returns the address given as bech32 or as a key name
+// in the local keyring.
+func genesisAccountAddress(cmd *cobra.Command, clientCtx client.Context, addrOrKeyName string) (sdk.AccAddress, error) {
+	addr, err := sdk.AccAddressFromBech32(addrOrKeyName)
+	if err == nil {
+		return addr, nil
+	}
+
+	var kr keyring.Keyring
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	keyringBackend, _ := cmd.Flags().GetString(flags.FlagKeyringBackend)
+	if keyringBackend != "" && clientCtx.Keyring == nil {
+		kr, err = keyring.New(sdk.KeyringServiceName(), keyringBackend, clientCtx.HomeDir, inBuf)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		kr = clientCtx.Keyring
+	}
+
+	info, err := kr.Key(addrOrKeyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get address from Keyring: %w", err)
+	}
+
+	return info.GetAddress(), nil
+}
+
+// vestingPeriodsFile is the JSON schedule read by --vesting-periods.
+type vestingPeriodsFile struct {
+	StartTime int64 `json:"start_time"`
+	Periods   []struct {
+		Length int64  `json:"length_seconds"`
+		Coins  string `json:"coins"`
+	} `json:"periods"`
+}
+
+// readVestingPeriods reads the start time and vesting periods from a JSON file.
+func readVestingPeriods(path string) (int64, authvesting.Periods, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read vesting periods file: %w", err)
+	}
+
+	var file vestingPeriodsFile
+	if err := json.Unmarshal(bz, &file); err != nil {
+		return 0, nil, fmt.Errorf("failed to parse vesting periods file: %w", err)
+	}
+
+	if len(file.Periods) == 0 {
+		return 0, nil, errors.New("vesting periods file must contain at least one period")
+	}
+
+	periods := make(authvesting.Periods, 0, len(file.Periods))
+	for i, p := range file.Periods {
+		if p.Length <= 0 {
+			return 0, nil, fmt.Errorf("vesting period %d: length must be positive, got %d", i+1, p.Length)
+		}
+
+		amount, err := sdk.ParseCoinsNormalized(p.Coins)
+		if err != nil {
+			return 0, nil, fmt.Errorf("vesting period %d: failed to parse coins: %w", i+1, err)
+		}
+
+		periods = append(periods, authvesting.Period{Length: p.Length, Amount: amount})
+	}
+
+	return file.StartTime, periods, nil
+}
+
+// newPeriodicVestingGenesisAccount creates a periodic vesting account which
+// vests the sum of the period amounts, starting at startTime.
+func newPeriodicVestingGenesisAccount(
+	addr sdk.AccAddress, coins sdk.Coins, startTime int64, periods authvesting.Periods,
+) (authtypes.GenesisAccount, banktypes.Balance, error) {
+	if startTime == 0 {
+		return nil, banktypes.Balance{}, fmt.Errorf("periodic vesting accounts need a start time; set start_time or --%s", flagVestingStart)
+	}
+
+	var originalVesting sdk.Coins
+	for _, p := range periods {
+		originalVesting = originalVesting.Add(p.Amount...)
+	}
+
+	balances := banktypes.Balance{Address: addr.String(), Coins: coins.Sort()}
+	// IsAnyGT 는 balance 에 없는 denom 을 건너뛰므로 IsAllLTE 로 확인한다.
+	if originalVesting.IsZero() || !originalVesting.IsAllLTE(balances.Coins) {
+		return nil, banktypes.Balance{}, errors.New("vesting amount cannot be greater than total amount")
+	}
+
+	baseAccount := authtypes.NewBaseAccount(addr, nil, 0, 0)
+	genAccount := authvesting.NewPeriodicVestingAccount(baseAccount, originalVesting.Sort(), startTime, periods)
+
+	if err := genAccount.Validate(); err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to validate new genesis account: %w", err)
+	}
+
+	return genAccount, balances, nil
+}
+
+// newPermanentLockedGenesisAccount creates an account whose locked coins never
+// vest. All coins are locked unless a smaller lockedAmt is given.
+func newPermanentLockedGenesisAccount(
+	addr sdk.AccAddress, coins, lockedAmt sdk.Coins,
+) (authtypes.GenesisAccount, banktypes.Balance, error) {
+	balances := banktypes.Balance{Address: addr.String(), Coins: coins.Sort()}
+	if lockedAmt.IsZero() {
+		lockedAmt = balances.Coins
+	}
+
+	if lockedAmt.IsZero() || !lockedAmt.IsAllLTE(balances.Coins) {
+		return nil, banktypes.Balance{}, errors.New("vesting amount cannot be greater than total amount")
+	}
+
+	baseAccount := authtypes.NewBaseAccount(addr, nil, 0, 0)
+	genAccount := authvesting.NewPermanentLockedAccount(baseAccount, lockedAmt.Sort())
+
+	if err := genAccount.Validate(); err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to validate new genesis account: %w", err)
+	}
+
+	return genAccount, balances, nil
+}
+
+// newModuleGenesisAccount creates a module account for the given module name.
+// Modules registered in the app must use their registered permissions, which
+// are also the default when no permissions are given.
+func newModuleGenesisAccount(
+	name string, perms []string, coins sdk.Coins,
+) (authtypes.GenesisAccount, banktypes.Balance, error) {
+	for _, perm := range perms {
+		switch perm {
+		case authtypes.Minter, authtypes.Burner, authtypes.Staking:
+		default:
+			return nil, banktypes.Balance{}, fmt.Errorf("invalid module account permission %q; must be one of %s, %s, %s",
+				perm, authtypes.Minter, authtypes.Burner, authtypes.Staking)
+		}
+	}
+
+	if registered, ok := jscapp.GetMaccPerms()[name]; ok {
+		if len(perms) == 0 {
+			perms = registered
+		} else if !sameStrings(perms, registered) {
+			return nil, banktypes.Balance{}, fmt.Errorf("module %s is registered with permissions %v, got %v", name, registered, perms)
+		}
+	}
+
+	genAccount := authtypes.NewEmptyModuleAccount(name, perms...)
+	balances := banktypes.Balance{Address: genAccount.GetAddress().String(), Coins: coins.Sort()}
+
+	if err := genAccount.Validate(); err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to validate new genesis account: %w", err)
+	}
+
+	return genAccount, balances, nil
+}
+
+// sameStrings reports whether a and b contain the same strings, ignoring order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	count := make(map[string]int, len(a))
+	for _, s := range a {
+		count[s]++
+	}
+	for _, s := range b {
+		count[s]--
+		if count[s] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -3,6 +3,8 @@ package cmd_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
@@ -13,7 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/log"
 
-	jscparams "github.com/Jeongseup/jeongseupchain/app/params"
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
 	jsccmd "github.com/Jeongseup/jeongseupchain/jeongseupd/cmd"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -21,8 +23,12 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
 	"github.com/cosmos/cosmos-sdk/types/module"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutiltest "github.com/cosmos/cosmos-sdk/x/genutil/client/testutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 )
 
 var testModuleBasicManager = module.NewBasicManager(genutil.AppModuleBasic{})
@@ -74,7 +80,7 @@ func TestAddGenesisAccountCmd(t *testing.T) {
 			cfg, err := genutiltest.CreateDefaultTendermintConfig(home)
 			require.NoError(t, err)
 
-			appCodec := jscparams.MakeEncodingConfig().Marshaler
+			appCodec := jscapp.MakeEncodingConfig().Marshaler
 			err = genutiltest.ExecInitCmd(testModuleBasicManager, home, appCodec)
 			require.NoError(t, err)
 
@@ -114,3 +120,148 @@ func TestAddGenesisAccountCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestAddGenesisAccountCmdAccountTypes(t *testing.T) {
+	_, _, addr1 := testdata.KeyTestPubAddr()
+	periodsFile := filepath.Join(t.TempDir(), "periods.json")
+	require.NoError(t, os.WriteFile(periodsFile, []byte(`{
+		"start_time": 1672531200,
+		"periods": [
+			{"length_seconds": 3600, "coins": "400stake"},
+			{"length_seconds": 3600, "coins": "600stake"}
+		]
+	}`), 0o600))
+	otherDenomPeriodsFile := filepath.Join(t.TempDir(), "periods.json")
+	require.NoError(t, os.WriteFile(otherDenomPeriodsFile, []byte(`{
+		"start_time": 1672531200,
+		"periods": [{"length_seconds": 3600, "coins": "100foo"}]
+	}`), 0o600))
+
+	tests := []struct {
+		name      string
+		args      []string
+		expectErr bool
+		check     func(t *testing.T, acc authtypes.GenesisAccount)
+	}{
+		{
+			name: "periodic vesting",
+			args: []string{addr1.String(), "1000stake", fmt.Sprintf("--%s=%s", "vesting-periods", periodsFile)},
+			check: func(t *testing.T, acc authtypes.GenesisAccount) {
+				pva, ok := acc.(*authvesting.PeriodicVestingAccount)
+				require.True(t, ok)
+				require.Equal(t, int64(1672531200), pva.StartTime)
+				require.Equal(t, int64(1672531200+7200), pva.EndTime)
+				require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)), pva.OriginalVesting)
+			},
+		},
+		{
+			name:      "periodic vesting exceeds balance",
+			args:      []string{addr1.String(), "999stake", fmt.Sprintf("--%s=%s", "vesting-periods", periodsFile)},
+			expectErr: true,
+		},
+		{
+			name:      "periodic vesting denom not in balance",
+			args:      []string{addr1.String(), "1000stake", fmt.Sprintf("--%s=%s", "vesting-periods", otherDenomPeriodsFile)},
+			expectErr: true,
+		},
+		{
+			name: "permanent locked",
+			args: []string{addr1.String(), "1000stake", "--permanent-locked", "--vesting-amount=300stake"},
+			check: func(t *testing.T, acc authtypes.GenesisAccount) {
+				pla, ok := acc.(*authvesting.PermanentLockedAccount)
+				require.True(t, ok)
+				require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 300)), pla.OriginalVesting)
+			},
+		},
+		{
+			name: "permanent locked defaults to all coins",
+			args: []string{addr1.String(), "1000stake", "--permanent-locked"},
+			check: func(t *testing.T, acc authtypes.GenesisAccount) {
+				pla, ok := acc.(*authvesting.PermanentLockedAccount)
+				require.True(t, ok)
+				require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)), pla.OriginalVesting)
+			},
+		},
+		{
+			name:      "permanent locked denom not in balance",
+			args:      []string{addr1.String(), "1000stake", "--permanent-locked", "--vesting-amount=100foo"},
+			expectErr: true,
+		},
+		{
+			name:      "continuous vesting denom not in balance",
+			args:      []string{addr1.String(), "1000stake", "--vesting-amount=100foo", "--vesting-start-time=1672531200", "--vesting-end-time=1672534800"},
+			expectErr: true,
+		},
+		{
+			name: "registered module account",
+			args: []string{minttypes.ModuleName, "1000stake", "--module-account"},
+			check: func(t *testing.T, acc authtypes.GenesisAccount) {
+				macc, ok := acc.(*authtypes.ModuleAccount)
+				require.True(t, ok)
+				require.Equal(t, minttypes.ModuleName, macc.Name)
+				require.Equal(t, []string{authtypes.Minter}, macc.Permissions)
+			},
+		},
+		{
+			name: "custom module account",
+			args: []string{"treasury", "1000stake", "--module-account", "--module-permissions=burner"},
+			check: func(t *testing.T, acc authtypes.GenesisAccount) {
+				macc, ok := acc.(*authtypes.ModuleAccount)
+				require.True(t, ok)
+				require.Equal(t, []string{authtypes.Burner}, macc.Permissions)
+			},
+		},
+		{
+			name:      "registered module account with other permissions",
+			args:      []string{minttypes.ModuleName, "1000stake", "--module-account", "--module-permissions=burner"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid module permission",
+			args:      []string{"treasury", "1000stake", "--module-account", "--module-permissions=owner"},
+			expectErr: true,
+		},
+		{
+			name:      "module account with vesting",
+			args:      []string{"treasury", "1000stake", "--module-account", "--permanent-locked"},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			home := t.TempDir()
+			cfg, err := genutiltest.CreateDefaultTendermintConfig(home)
+			require.NoError(t, err)
+
+			appCodec := jscapp.MakeEncodingConfig().Marshaler
+			require.NoError(t, genutiltest.ExecInitCmd(testModuleBasicManager, home, appCodec))
+
+			serverCtx := server.NewContext(viper.New(), cfg, log.NewNopLogger())
+			clientCtx := client.Context{}.WithCodec(appCodec).WithHomeDir(home)
+
+			ctx := context.Background()
+			ctx = context.WithValue(ctx, client.ClientContextKey, &clientCtx)
+			ctx = context.WithValue(ctx, server.ServerContextKey, serverCtx)
+
+			cmd := jsccmd.AddGenesisAccountCmd(home)
+			cmd.SetArgs(tc.args)
+
+			if tc.expectErr {
+				require.Error(t, cmd.ExecuteContext(ctx))
+				return
+			}
+			require.NoError(t, cmd.ExecuteContext(ctx))
+
+			appState, _, err := genutiltypes.GenesisStateFromGenFile(cfg.GenesisFile())
+			require.NoError(t, err)
+
+			authGenState := authtypes.GetGenesisStateFromAppState(appCodec, appState)
+			accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+			require.NoError(t, err)
+			require.Len(t, accs, 1)
+			tc.check(t, accs[0])
+		})
+	}
+}
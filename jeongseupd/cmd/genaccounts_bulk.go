@@ -0,0 +1,363 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+)
+
+const (
+	flagBulkFormat = "format"
+
+	bulkFormatCSV  = "csv"
+	bulkFormatJSON = "json"
+
+	// 에러가 많을 때 전부 출력하지 않고 앞에서부터 이만큼만 보여줌
+	maxBulkRowErrors = 20
+)
+
+// bulkGenesisAccount is a single row of the add-genesis-accounts-bulk input file.
+type bulkGenesisAccount struct {
+	Address       string `json:"address"`
+	Coins         string `json:"coins"`
+	VestingStart  int64  `json:"vesting_start,omitempty"`
+	VestingEnd    int64  `json:"vesting_end,omitempty"`
+	VestingAmount string `json:"vesting_amount,omitempty"`
+
+	// position of the row in the input file, used in error messages
+	source string
+}
+
+// AddGenesisAccountsBulkCmd returns add-genesis-accounts-bulk cobra Command.
+func AddGenesisAccountsBulkCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-genesis-accounts-bulk [file]",
+		Short: "Add many genesis accounts to genesis.json from a CSV or JSON file",
+		Long: `Add many genesis accounts to genesis.json in a single pass. Every row is validated
+with the same rules as add-genesis-account, and the command fails without touching
+genesis.json if any row is invalid or its address already exists in the genesis or
+earlier in the file.
+
+CSV rows are "address,coins[,vesting_start,vesting_end,vesting_amount]", with an optional
+header line. Quote coins lists that contain commas:
+
+	address,coins,vesting_start,vesting_end,vesting_amount
+	jeongseup1...,"1000stake,500ujeong",,,
+	jeongseup1...,1000stake,1672531200,1704067200,500stake
+
+JSON files contain an array of objects:
+
+	[{"address": "jeongseup1...", "coins": "1000stake", "vesting_end": 1704067200, "vesting_amount": "500stake"}]
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+
+			config.SetRoot(clientCtx.HomeDir)
+
+			format, _ := cmd.Flags().GetString(flagBulkFormat)
+			rows, err := readBulkGenesisAccounts(args[0], format)
+			if err != nil {
+				return err
+			}
+
+			genFile := config.GenesisFile()
+			appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			authGenState := authtypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+
+			accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+			if err != nil {
+				return fmt.Errorf("failed to get accounts from any: %w", err)
+			}
+
+			// accs.Contains는 매번 전체를 도니까 map으로 중복 검사
+			existing := make(map[string]bool, len(accs))
+			for _, acc := range accs {
+				existing[acc.GetAddress().String()] = true
+			}
+
+			var (
+				newAccs     authtypes.GenesisAccounts
+				newBalances []banktypes.Balance
+				rowErrs     []string
+				summary     bulkImportSummary
+			)
+
+			seen := make(map[string]string, len(rows))
+			for _, row := range rows {
+				genAccount, balance, err := parseBulkGenesisAccount(row)
+				if err != nil {
+					rowErrs = append(rowErrs, fmt.Sprintf("%s: %s", row.source, err))
+					continue
+				}
+
+				addr := balance.Address
+				if existing[addr] {
+					rowErrs = append(rowErrs, fmt.Sprintf("%s: cannot add account at existing address %s", row.source, addr))
+					continue
+				}
+				if prev, ok := seen[addr]; ok {
+					rowErrs = append(rowErrs, fmt.Sprintf("%s: duplicate address %s, first seen at %s", row.source, addr, prev))
+					continue
+				}
+				seen[addr] = row.source
+
+				newAccs = append(newAccs, genAccount)
+				newBalances = append(newBalances, balance)
+				summary.add(genAccount, balance)
+			}
+
+			if len(rowErrs) > 0 {
+				return newBulkRowsError(rowErrs)
+			}
+
+			// Add the new accounts to the set of genesis accounts and sanitize the
+			// accounts afterwards.
+			accs = append(accs, newAccs...)
+			accs = authtypes.SanitizeGenesisAccounts(accs)
+
+			genAccs, err := authtypes.PackAccounts(accs)
+			if err != nil {
+				return fmt.Errorf("failed to convert accounts into any's: %w", err)
+			}
+			authGenState.Accounts = genAccs
+
+			authGenStateBz, err := clientCtx.Codec.MarshalJSON(&authGenState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal auth genesis state: %w", err)
+			}
+
+			appState[authtypes.ModuleName] = authGenStateBz
+
+			bankGenState := banktypes.GetGenesisStateFromAppState(clientCtx.Codec, appState)
+			bankGenState.Balances = append(bankGenState.Balances, newBalances...)
+			bankGenState.Balances = banktypes.SanitizeGenesisBalances(bankGenState.Balances)
+			bankGenState.Supply = bankGenState.Supply.Add(summary.totalCoins...)
+
+			bankGenStateBz, err := clientCtx.Codec.MarshalJSON(bankGenState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal bank genesis state: %w", err)
+			}
+
+			appState[banktypes.ModuleName] = bankGenStateBz
+
+			appStateJSON, err := json.Marshal(appState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal application genesis state: %w", err)
+			}
+
+			genDoc.AppState = appStateJSON
+			if err := genutil.ExportGenesisFile(genDoc, genFile); err != nil {
+				return err
+			}
+
+			summary.totalAccounts = len(accs)
+			return summary.print(cmd.OutOrStdout(), args[0])
+		},
+	}
+
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(flagBulkFormat, "", "Input file format (csv|json); detected from the file extension when empty")
+
+	return cmd
+}
+
+// parseBulkGenesisAccount parses a single input row into a validated genesis
+// account and its balance.
+func parseBulkGenesisAccount(row bulkGenesisAccount) (authtypes.GenesisAccount, banktypes.Balance, error) {
+	addr, err := sdk.AccAddressFromBech32(strings.TrimSpace(row.Address))
+	if err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("invalid address %q: %w", row.Address, err)
+	}
+
+	coins, err := sdk.ParseCoinsNormalized(row.Coins)
+	if err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to parse coins: %w", err)
+	}
+
+	vestingAmt, err := sdk.ParseCoinsNormalized(row.VestingAmount)
+	if err != nil {
+		return nil, banktypes.Balance{}, fmt.Errorf("failed to parse vesting amount: %w", err)
+	}
+
+	return newGenesisAccount(addr, coins, vestingAmt, row.VestingStart, row.VestingEnd)
+}
+
+// readBulkGenesisAccounts reads all rows of a CSV or JSON input file.
+func readBulkGenesisAccounts(path, format string) ([]bulkGenesisAccount, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rows []bulkGenesisAccount
+	switch format {
+	case bulkFormatCSV:
+		rows, err = readBulkGenesisAccountsCSV(f)
+	case bulkFormatJSON:
+		rows, err = readBulkGenesisAccountsJSON(f)
+	default:
+		return nil, fmt.Errorf("unsupported input format %q; use --%s=csv or --%s=json", format, flagBulkFormat, flagBulkFormat)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no accounts found in %s", path)
+	}
+
+	return rows, nil
+}
+
+func readBulkGenesisAccountsCSV(r io.Reader) ([]bulkGenesisAccount, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []bulkGenesisAccount
+	for i := 0; ; i++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		// 첫 줄이 header면 건너뜀
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			continue
+		}
+
+		if len(record) != 2 && len(record) != 5 {
+			return nil, fmt.Errorf("line %d: expected 2 or 5 columns, got %d", line, len(record))
+		}
+
+		row := bulkGenesisAccount{
+			Address: record[0],
+			Coins:   record[1],
+			source:  fmt.Sprintf("line %d", line),
+		}
+
+		if len(record) == 5 {
+			if row.VestingStart, err = parseOptionalInt64(record[2]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid vesting start: %w", line, err)
+			}
+			if row.VestingEnd, err = parseOptionalInt64(record[3]); err != nil {
+				return nil, fmt.Errorf("line %d: invalid vesting end: %w", line, err)
+			}
+			row.VestingAmount = record[4]
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func readBulkGenesisAccountsJSON(r io.Reader) ([]bulkGenesisAccount, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var rows []bulkGenesisAccount
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].source = fmt.Sprintf("entry %d", i+1)
+	}
+
+	return rows, nil
+}
+
+func parseOptionalInt64(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func newBulkRowsError(rowErrs []string) error {
+	shown := rowErrs
+	if len(shown) > maxBulkRowErrors {
+		shown = shown[:maxBulkRowErrors]
+	}
+
+	msg := fmt.Sprintf("%d invalid rows, genesis.json was not modified:\n  %s", len(rowErrs), strings.Join(shown, "\n  "))
+	if len(rowErrs) > len(shown) {
+		msg += fmt.Sprintf("\n  ... and %d more", len(rowErrs)-len(shown))
+	}
+
+	return errors.New(msg)
+}
+
+// bulkImportSummary counts the imported accounts by type for the report.
+type bulkImportSummary struct {
+	base              int
+	continuousVesting int
+	delayedVesting    int
+	totalCoins        sdk.Coins
+	totalAccounts     int
+}
+
+func (s *bulkImportSummary) add(genAccount authtypes.GenesisAccount, balance banktypes.Balance) {
+	switch genAccount.(type) {
+	case *authvesting.ContinuousVestingAccount:
+		s.continuousVesting++
+	case *authvesting.DelayedVestingAccount:
+		s.delayedVesting++
+	default:
+		s.base++
+	}
+
+	s.totalCoins = s.totalCoins.Add(balance.Coins...)
+}
+
+func (s bulkImportSummary) print(w io.Writer, path string) error {
+	_, err := fmt.Fprintf(w, `imported %d genesis accounts from %s
+  base accounts:               %d
+  continuous vesting accounts: %d
+  delayed vesting accounts:    %d
+  total coins added:           %s
+  genesis accounts in total:   %d
+`,
+		s.base+s.continuousVesting+s.delayedVesting, path,
+		s.base, s.continuousVesting, s.delayedVesting, s.totalCoins, s.totalAccounts,
+	)
+	return err
+}
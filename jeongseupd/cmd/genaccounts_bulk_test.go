@@ -0,0 +1,157 @@
+package cmd_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	jsccmd "github.com/Jeongseup/jeongseupchain/jeongseupd/cmd"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	genutiltest "github.com/cosmos/cosmos-sdk/x/genutil/client/testutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+)
+
+func TestAddGenesisAccountsBulkCmd(t *testing.T) {
+	_, _, addr1 := testdata.KeyTestPubAddr()
+	_, _, addr2 := testdata.KeyTestPubAddr()
+	_, _, addr3 := testdata.KeyTestPubAddr()
+
+	tests := []struct {
+		name      string
+		fileName  string
+		contents  string
+		existing  string
+		expectErr string
+		expected  int
+	}{
+		{
+			name:     "csv with header and vesting row",
+			fileName: "accounts.csv",
+			contents: fmt.Sprintf("address,coins,vesting_start,vesting_end,vesting_amount\n%s,\"1000stake,20atom\"\n%s,1000stake,1000,2000,500stake\n",
+				addr1, addr2),
+			expected: 2,
+		},
+		{
+			name:     "json",
+			fileName: "accounts.json",
+			contents: fmt.Sprintf(`[{"address":"%s","coins":"1000stake"},{"address":"%s","coins":"1000stake","vesting_end":2000,"vesting_amount":"10stake"}]`,
+				addr1, addr2),
+			expected: 2,
+		},
+		{
+			name:      "duplicate within file",
+			fileName:  "accounts.csv",
+			contents:  fmt.Sprintf("%s,1000stake\n%s,10stake\n", addr1, addr1),
+			expectErr: "duplicate address",
+		},
+		{
+			name:      "duplicate against genesis",
+			fileName:  "accounts.csv",
+			contents:  fmt.Sprintf("%s,1000stake\n%s,10stake\n", addr3, addr1),
+			existing:  addr1.String(),
+			expectErr: "existing address",
+		},
+		{
+			name:      "vesting greater than total",
+			fileName:  "accounts.csv",
+			contents:  fmt.Sprintf("%s,10stake,1000,2000,500stake\n", addr1),
+			expectErr: "vesting amount cannot be greater than total amount",
+		},
+		{
+			name:      "invalid address",
+			fileName:  "accounts.json",
+			contents:  `[{"address":"invalid","coins":"1000stake"}]`,
+			expectErr: "invalid address",
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			home := t.TempDir()
+			cfg, err := genutiltest.CreateDefaultTendermintConfig(home)
+			require.NoError(t, err)
+
+			appCodec := jscapp.MakeEncodingConfig().Marshaler
+			err = genutiltest.ExecInitCmd(testModuleBasicManager, home, appCodec)
+			require.NoError(t, err)
+
+			serverCtx := server.NewContext(viper.New(), cfg, log.NewNopLogger())
+			clientCtx := client.Context{}.WithCodec(appCodec).WithHomeDir(home)
+
+			ctx := context.Background()
+			ctx = context.WithValue(ctx, client.ClientContextKey, &clientCtx)
+			ctx = context.WithValue(ctx, server.ServerContextKey, serverCtx)
+
+			if tc.existing != "" {
+				cmd := jsccmd.AddGenesisAccountCmd(home)
+				cmd.SetArgs([]string{tc.existing, "1stake"})
+				require.NoError(t, cmd.ExecuteContext(ctx))
+			}
+
+			genFile := cfg.GenesisFile()
+			before, err := os.ReadFile(genFile)
+			require.NoError(t, err)
+
+			path := filepath.Join(t.TempDir(), tc.fileName)
+			require.NoError(t, os.WriteFile(path, []byte(tc.contents), 0o600))
+
+			cmd := jsccmd.AddGenesisAccountsBulkCmd(home)
+			cmd.SetArgs([]string{path})
+			var out strings.Builder
+			cmd.SetOut(&out)
+
+			err = cmd.ExecuteContext(ctx)
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+
+				// 실패하면 genesis.json은 그대로여야 함
+				after, err := os.ReadFile(genFile)
+				require.NoError(t, err)
+				require.Equal(t, before, after)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, out.String(), fmt.Sprintf("imported %d genesis accounts", tc.expected))
+
+			appState, _, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			require.NoError(t, err)
+
+			authGenState := authtypes.GetGenesisStateFromAppState(appCodec, appState)
+			accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+			require.NoError(t, err)
+			require.Len(t, accs, tc.expected)
+
+			var vestingAccounts int
+			for _, acc := range accs {
+				if _, ok := acc.(vestingexported.VestingAccount); ok {
+					vestingAccounts++
+				}
+			}
+			require.Equal(t, 1, vestingAccounts)
+
+			bankGenState := banktypes.GetGenesisStateFromAppState(appCodec, appState)
+			require.Len(t, bankGenState.Balances, tc.expected)
+
+			var total sdk.Coins
+			for _, balance := range bankGenState.Balances {
+				total = total.Add(balance.Coins...)
+			}
+			require.Equal(t, total, bankGenState.Supply)
+		})
+	}
+}
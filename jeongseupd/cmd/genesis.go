@@ -0,0 +1,463 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// genesisSetter applies a single key edit to the genesis document. Module
+// setters edit appState, consensus setters edit genDoc.ConsensusParams.
+type genesisSetter func(cdc codec.JSONCodec, genDoc *tmtypes.GenesisDoc, appState map[string]json.RawMessage, value string) error
+
+// GenesisCmd returns the genesis command group used to edit and audit genesis.json.
+func GenesisCmd(mbm module.BasicManager, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "genesis",
+		Short:                      "Edit and audit the genesis file",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.PersistentFlags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.AddCommand(
+		GenesisSetCmd(mbm),
+		GenesisAuditCmd(),
+	)
+
+	return cmd
+}
+
+// GenesisSetCmd returns the `genesis set` command group. Every subcommand
+// edits one module (or the consensus params) and validates the result with
+// the module basics before writing genesis.json.
+func GenesisSetCmd(mbm module.BasicManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "set",
+		Short:                      "Set a module or consensus parameter in genesis.json",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		genesisSetModuleCmd(mbm, stakingtypes.ModuleName, stakingGenesisSetters()),
+		genesisSetModuleCmd(mbm, banktypes.ModuleName, bankGenesisSetters()),
+		genesisSetModuleCmd(mbm, minttypes.ModuleName, mintGenesisSetters()),
+		genesisSetModuleCmd(mbm, govtypes.ModuleName, govGenesisSetters()),
+		genesisSetModuleCmd(mbm, slashingtypes.ModuleName, slashingGenesisSetters()),
+		genesisSetModuleCmd(mbm, crisistypes.ModuleName, crisisGenesisSetters()),
+		genesisSetModuleCmd(mbm, "consensus", consensusGenesisSetters()),
+	)
+
+	return cmd
+}
+
+func genesisSetModuleCmd(mbm module.BasicManager, name string, setters map[string]genesisSetter) *cobra.Command {
+	keys := make([]string, 0, len(setters))
+	for key := range setters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return &cobra.Command{
+		Use:       fmt.Sprintf("%s [key] [value]", name),
+		Short:     fmt.Sprintf("Set a %s parameter in genesis.json", name),
+		Long:      fmt.Sprintf("Set a %s parameter in genesis.json.\n\nSupported keys:\n  %s", name, strings.Join(keys, "\n  ")),
+		Example:   fmt.Sprintf("$ jeongseupd genesis set %s %s <value>", name, keys[0]),
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: keys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+
+			config.SetRoot(clientCtx.HomeDir)
+
+			set, ok := setters[args[0]]
+			if !ok {
+				return fmt.Errorf("unknown %s key %q; supported keys: %s", name, args[0], strings.Join(keys, ", "))
+			}
+
+			genFile := config.GenesisFile()
+			appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			if err := set(clientCtx.Codec, genDoc, appState, args[1]); err != nil {
+				return fmt.Errorf("failed to set %s %s: %w", name, args[0], err)
+			}
+
+			if err := mbm.ValidateGenesis(clientCtx.Codec, clientCtx.TxConfig, appState); err != nil {
+				return fmt.Errorf("invalid genesis after setting %s %s: %w", name, args[0], err)
+			}
+
+			if genDoc.ConsensusParams != nil {
+				if err := tmtypes.ValidateConsensusParams(*genDoc.ConsensusParams); err != nil {
+					return fmt.Errorf("invalid consensus params after setting %s: %w", args[0], err)
+				}
+			}
+
+			appStateJSON, err := json.Marshal(appState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal application genesis state: %w", err)
+			}
+
+			genDoc.AppState = appStateJSON
+			return genutil.ExportGenesisFile(genDoc, genFile)
+		},
+	}
+}
+
+// editModuleGenesis unmarshals the genesis of moduleName into genState, runs
+// edit and writes the result back into appState.
+func editModuleGenesis(
+	cdc codec.JSONCodec, appState map[string]json.RawMessage, moduleName string,
+	genState codec.ProtoMarshaler, edit func() error,
+) error {
+	bz, ok := appState[moduleName]
+	if !ok {
+		return fmt.Errorf("%s genesis not found", moduleName)
+	}
+
+	if err := cdc.UnmarshalJSON(bz, genState); err != nil {
+		return fmt.Errorf("failed to unmarshal %s genesis state: %w", moduleName, err)
+	}
+
+	if err := edit(); err != nil {
+		return err
+	}
+
+	bz, err := cdc.MarshalJSON(genState)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s genesis state: %w", moduleName, err)
+	}
+
+	appState[moduleName] = bz
+	return nil
+}
+
+func stakingGenesisSetters() map[string]genesisSetter {
+	edit := func(fn func(*stakingtypes.GenesisState, string) error) genesisSetter {
+		return func(cdc codec.JSONCodec, _ *tmtypes.GenesisDoc, appState map[string]json.RawMessage, value string) error {
+			var genState stakingtypes.GenesisState
+			return editModuleGenesis(cdc, appState, stakingtypes.ModuleName, &genState, func() error {
+				return fn(&genState, value)
+			})
+		}
+	}
+
+	return map[string]genesisSetter{
+		"bond-denom": setBondDenom,
+		"unbonding-time": edit(func(gs *stakingtypes.GenesisState, v string) (err error) {
+			gs.Params.UnbondingTime, err = time.ParseDuration(v)
+			return err
+		}),
+		"max-validators": edit(func(gs *stakingtypes.GenesisState, v string) error {
+			n, err := strconv.ParseUint(v, 10, 32)
+			gs.Params.MaxValidators = uint32(n)
+			return err
+		}),
+		"max-entries": edit(func(gs *stakingtypes.GenesisState, v string) error {
+			n, err := strconv.ParseUint(v, 10, 32)
+			gs.Params.MaxEntries = uint32(n)
+			return err
+		}),
+		"historical-entries": edit(func(gs *stakingtypes.GenesisState, v string) error {
+			n, err := strconv.ParseUint(v, 10, 32)
+			gs.Params.HistoricalEntries = uint32(n)
+			return err
+		}),
+	}
+}
+
+// setBondDenom sets the staking bond denom. The mint denom, the gov min deposit
+// and the crisis constant fee which still use the old bond denom are moved
+// along, otherwise the chain mints and charges fees in a denom nobody can bond.
+// Balances are not touched. The bond denom cannot be changed once gentxs are
+// collected, since their self-delegations are signed in the old denom and
+// InitChain would fail on the first one.
+func setBondDenom(cdc codec.JSONCodec, _ *tmtypes.GenesisDoc, appState map[string]json.RawMessage, value string) error {
+	if err := sdk.ValidateDenom(value); err != nil {
+		return err
+	}
+
+	genTxs := genutiltypes.GetGenesisStateFromAppState(cdc, appState).GenTxs
+
+	var oldDenom string
+	var stakingGenState stakingtypes.GenesisState
+	if err := editModuleGenesis(cdc, appState, stakingtypes.ModuleName, &stakingGenState, func() error {
+		oldDenom = stakingGenState.Params.BondDenom
+		if oldDenom != value && len(genTxs) > 0 {
+			return fmt.Errorf(
+				"cannot change the bond denom from %s to %s: genesis has %d gentxs self-delegating %s, set the bond denom before collect-gentxs",
+				oldDenom, value, len(genTxs), oldDenom,
+			)
+		}
+
+		stakingGenState.Params.BondDenom = value
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if oldDenom == value {
+		return nil
+	}
+
+	var mintGenState minttypes.GenesisState
+	if err := editModuleGenesis(cdc, appState, minttypes.ModuleName, &mintGenState, func() error {
+		if mintGenState.Params.MintDenom == oldDenom {
+			mintGenState.Params.MintDenom = value
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var govGenState govtypes.GenesisState
+	if err := editModuleGenesis(cdc, appState, govtypes.ModuleName, &govGenState, func() error {
+		var minDeposit sdk.Coins
+		for _, coin := range govGenState.DepositParams.MinDeposit {
+			if coin.Denom == oldDenom {
+				coin.Denom = value
+			}
+			minDeposit = minDeposit.Add(coin)
+		}
+		govGenState.DepositParams.MinDeposit = minDeposit
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var crisisGenState crisistypes.GenesisState
+	return editModuleGenesis(cdc, appState, crisistypes.ModuleName, &crisisGenState, func() error {
+		if crisisGenState.ConstantFee.Denom == oldDenom {
+			crisisGenState.ConstantFee.Denom = value
+		}
+		return nil
+	})
+}
+
+func bankGenesisSetters() map[string]genesisSetter {
+	edit := func(fn func(codec.JSONCodec, *banktypes.GenesisState, string) error) genesisSetter {
+		return func(cdc codec.JSONCodec, _ *tmtypes.GenesisDoc, appState map[string]json.RawMessage, value string) error {
+			var genState banktypes.GenesisState
+			return editModuleGenesis(cdc, appState, banktypes.ModuleName, &genState, func() error {
+				return fn(cdc, &genState, value)
+			})
+		}
+	}
+
+	return map[string]genesisSetter{
+		"default-send-enabled": edit(func(_ codec.JSONCodec, gs *banktypes.GenesisState, v string) (err error) {
+			gs.Params.DefaultSendEnabled, err = strconv.ParseBool(v)
+			return err
+		}),
+		// value 는 Metadata JSON 또는 JSON 파일 경로. 같은 base denom 이 있으면 교체한다.
+		"denom-metadata": edit(func(cdc codec.JSONCodec, gs *banktypes.GenesisState, v string) error {
+			bz := []byte(v)
+			if !json.Valid(bz) {
+				var err error
+				if bz, err = os.ReadFile(v); err != nil {
+					return fmt.Errorf("value is neither metadata JSON nor a readable file: %w", err)
+				}
+			}
+
+			var metadata banktypes.Metadata
+			if err := cdc.UnmarshalJSON(bz, &metadata); err != nil {
+				return fmt.Errorf("failed to parse denom metadata: %w", err)
+			}
+
+			for i, m := range gs.DenomMetadata {
+				if m.Base == metadata.Base {
+					gs.DenomMetadata[i] = metadata
+					return nil
+				}
+			}
+
+			gs.DenomMetadata = append(gs.DenomMetadata, metadata)
+			return nil
+		}),
+	}
+}
+
+func mintGenesisSetters() map[string]genesisSetter {
+	edit := func(fn func(*minttypes.GenesisState, string) error) genesisSetter {
+		return func(cdc codec.JSONCodec, _ *tmtypes.GenesisDoc, appState map[string]json.RawMessage, value string) error {
+			var genState minttypes.GenesisState
+			return editModuleGenesis(cdc, appState, minttypes.ModuleName, &genState, func() error {
+				return fn(&genState, value)
+			})
+		}
+	}
+
+	return map[string]genesisSetter{
+		"mint-denom": edit(func(gs *minttypes.GenesisState, v string) error {
+			gs.Params.MintDenom = v
+			return nil
+		}),
+		"inflation": edit(func(gs *minttypes.GenesisState, v string) (err error) {
+			gs.Minter.Inflation, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+		"inflation-max": edit(func(gs *minttypes.GenesisState, v string) (err error) {
+			gs.Params.InflationMax, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+		"inflation-min": edit(func(gs *minttypes.GenesisState, v string) (err error) {
+			gs.Params.InflationMin, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+		"goal-bonded": edit(func(gs *minttypes.GenesisState, v string) (err error) {
+			gs.Params.GoalBonded, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+		"blocks-per-year": edit(func(gs *minttypes.GenesisState, v string) (err error) {
+			gs.Params.BlocksPerYear, err = strconv.ParseUint(v, 10, 64)
+			return err
+		}),
+	}
+}
+
+func govGenesisSetters() map[string]genesisSetter {
+	edit := func(fn func(*govtypes.GenesisState, string) error) genesisSetter {
+		return func(cdc codec.JSONCodec, _ *tmtypes.GenesisDoc, appState map[string]json.RawMessage, value string) error {
+			var genState govtypes.GenesisState
+			return editModuleGenesis(cdc, appState, govtypes.ModuleName, &genState, func() error {
+				return fn(&genState, value)
+			})
+		}
+	}
+
+	return map[string]genesisSetter{
+		"min-deposit": edit(func(gs *govtypes.GenesisState, v string) (err error) {
+			gs.DepositParams.MinDeposit, err = sdk.ParseCoinsNormalized(v)
+			return err
+		}),
+		"max-deposit-period": edit(func(gs *govtypes.GenesisState, v string) (err error) {
+			gs.DepositParams.MaxDepositPeriod, err = time.ParseDuration(v)
+			return err
+		}),
+		"voting-period": edit(func(gs *govtypes.GenesisState, v string) (err error) {
+			gs.VotingParams.VotingPeriod, err = time.ParseDuration(v)
+			return err
+		}),
+		"quorum": edit(func(gs *govtypes.GenesisState, v string) (err error) {
+			gs.TallyParams.Quorum, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+		"threshold": edit(func(gs *govtypes.GenesisState, v string) (err error) {
+			gs.TallyParams.Threshold, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+		"veto-threshold": edit(func(gs *govtypes.GenesisState, v string) (err error) {
+			gs.TallyParams.VetoThreshold, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+	}
+}
+
+func slashingGenesisSetters() map[string]genesisSetter {
+	edit := func(fn func(*slashingtypes.GenesisState, string) error) genesisSetter {
+		return func(cdc codec.JSONCodec, _ *tmtypes.GenesisDoc, appState map[string]json.RawMessage, value string) error {
+			var genState slashingtypes.GenesisState
+			return editModuleGenesis(cdc, appState, slashingtypes.ModuleName, &genState, func() error {
+				return fn(&genState, value)
+			})
+		}
+	}
+
+	return map[string]genesisSetter{
+		"signed-blocks-window": edit(func(gs *slashingtypes.GenesisState, v string) (err error) {
+			gs.Params.SignedBlocksWindow, err = strconv.ParseInt(v, 10, 64)
+			return err
+		}),
+		"min-signed-per-window": edit(func(gs *slashingtypes.GenesisState, v string) (err error) {
+			gs.Params.MinSignedPerWindow, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+		"downtime-jail-duration": edit(func(gs *slashingtypes.GenesisState, v string) (err error) {
+			gs.Params.DowntimeJailDuration, err = time.ParseDuration(v)
+			return err
+		}),
+		"slash-fraction-double-sign": edit(func(gs *slashingtypes.GenesisState, v string) (err error) {
+			gs.Params.SlashFractionDoubleSign, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+		"slash-fraction-downtime": edit(func(gs *slashingtypes.GenesisState, v string) (err error) {
+			gs.Params.SlashFractionDowntime, err = sdk.NewDecFromStr(v)
+			return err
+		}),
+	}
+}
+
+func crisisGenesisSetters() map[string]genesisSetter {
+	return map[string]genesisSetter{
+		"constant-fee": func(cdc codec.JSONCodec, _ *tmtypes.GenesisDoc, appState map[string]json.RawMessage, value string) error {
+			var genState crisistypes.GenesisState
+			return editModuleGenesis(cdc, appState, crisistypes.ModuleName, &genState, func() (err error) {
+				genState.ConstantFee, err = sdk.ParseCoinNormalized(value)
+				return err
+			})
+		},
+	}
+}
+
+// consensusGenesisSetters edit the tendermint consensus params of the genesis
+// document; keys follow the consensus_params JSON layout.
+func consensusGenesisSetters() map[string]genesisSetter {
+	edit := func(fn func(*tmtypes.GenesisDoc, string) error) genesisSetter {
+		return func(_ codec.JSONCodec, genDoc *tmtypes.GenesisDoc, _ map[string]json.RawMessage, value string) error {
+			if genDoc.ConsensusParams == nil {
+				genDoc.ConsensusParams = tmtypes.DefaultConsensusParams()
+			}
+			return fn(genDoc, value)
+		}
+	}
+
+	return map[string]genesisSetter{
+		"block.max_bytes": edit(func(genDoc *tmtypes.GenesisDoc, v string) (err error) {
+			genDoc.ConsensusParams.Block.MaxBytes, err = strconv.ParseInt(v, 10, 64)
+			return err
+		}),
+		"block.max_gas": edit(func(genDoc *tmtypes.GenesisDoc, v string) (err error) {
+			genDoc.ConsensusParams.Block.MaxGas, err = strconv.ParseInt(v, 10, 64)
+			return err
+		}),
+		"evidence.max_age_num_blocks": edit(func(genDoc *tmtypes.GenesisDoc, v string) (err error) {
+			genDoc.ConsensusParams.Evidence.MaxAgeNumBlocks, err = strconv.ParseInt(v, 10, 64)
+			return err
+		}),
+		"evidence.max_age_duration": edit(func(genDoc *tmtypes.GenesisDoc, v string) (err error) {
+			genDoc.ConsensusParams.Evidence.MaxAgeDuration, err = time.ParseDuration(v)
+			return err
+		}),
+		"evidence.max_bytes": edit(func(genDoc *tmtypes.GenesisDoc, v string) (err error) {
+			genDoc.ConsensusParams.Evidence.MaxBytes, err = strconv.ParseInt(v, 10, 64)
+			return err
+		}),
+	}
+}
@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+const (
+	auditCheckSupply        = "bank_supply"
+	auditCheckStakingPools  = "staking_pools"
+	auditCheckGentxBalances = "gentx_balances"
+	auditCheckBlockedAddrs  = "blocked_addresses"
+	auditCheckVesting       = "vesting_balances"
+	auditCheckDenomMetadata = "denom_metadata"
+)
+
+// genesisAuditReport is the JSON report printed by `genesis audit`.
+type genesisAuditReport struct {
+	GenesisFile string              `json:"genesis_file"`
+	Valid       bool                `json:"valid"`
+	Checks      []genesisAuditCheck `json:"checks"`
+}
+
+type genesisAuditCheck struct {
+	Name   string   `json:"name"`
+	Passed bool     `json:"passed"`
+	Issues []string `json:"issues,omitempty"`
+}
+
+func (c *genesisAuditCheck) addIssue(format string, args ...interface{}) {
+	c.Issues = append(c.Issues, fmt.Sprintf(format, args...))
+}
+
+// GenesisAuditCmd returns the `genesis audit` command which runs cross-module
+// consistency checks that each module's ValidateGenesis cannot do on its own.
+func GenesisAuditCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "audit [genesis-file]",
+		Short: "Run cross-module consistency checks on genesis.json and print a JSON report",
+		Long: `Run cross-module consistency checks on genesis.json and print a JSON report.
+
+The following checks are run:
+  bank_supply        bank supply equals the sum of all balances
+  staking_pools      bonded and not-bonded pool balances match the staking state
+  gentx_balances     every gentx delegator has enough balance in the bond denom
+  blocked_addresses  no regular genesis account uses a module account address
+  vesting_balances   vesting amounts are at most the account balances
+  denom_metadata     every denom has bank denom metadata
+
+The command exits with an error if any check fails. If no file is given, the
+genesis file of the node home is audited.
+`,
+		Args: cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			config := serverCtx.Config
+
+			config.SetRoot(clientCtx.HomeDir)
+
+			genFile := config.GenesisFile()
+			if len(args) == 1 {
+				genFile = args[0]
+			}
+
+			appState, _, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			report, err := auditGenesis(clientCtx.Codec, clientCtx.TxConfig, appState)
+			if err != nil {
+				return err
+			}
+			report.GenesisFile = genFile
+
+			bz, err := json.MarshalIndent(report, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), string(bz))
+
+			if !report.Valid {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("genesis audit failed")
+			}
+
+			return nil
+		},
+	}
+}
+
+// auditGenesis runs all audit checks on the given app state. An error is only
+// returned when the state cannot be decoded; failed checks are in the report.
+func auditGenesis(cdc codec.Codec, txConfig client.TxEncodingConfig, appState map[string]json.RawMessage) (genesisAuditReport, error) {
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, appState)
+	accs, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return genesisAuditReport{}, fmt.Errorf("failed to get accounts from any: %w", err)
+	}
+
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
+
+	var stakingGenState stakingtypes.GenesisState
+	if bz, ok := appState[stakingtypes.ModuleName]; ok {
+		if err := cdc.UnmarshalJSON(bz, &stakingGenState); err != nil {
+			return genesisAuditReport{}, fmt.Errorf("failed to unmarshal staking genesis state: %w", err)
+		}
+	}
+
+	genutilGenState := genutiltypes.GetGenesisStateFromAppState(cdc, appState)
+
+	balances := make(map[string]sdk.Coins, len(bankGenState.Balances))
+	for _, b := range bankGenState.Balances {
+		balances[b.Address] = balances[b.Address].Add(b.Coins...)
+	}
+
+	report := genesisAuditReport{
+		Checks: []genesisAuditCheck{
+			auditSupply(bankGenState),
+			auditStakingPools(stakingGenState, balances),
+			auditGentxBalances(txConfig, genutilGenState, stakingGenState.Params.BondDenom, balances),
+			auditBlockedAddrs(accs),
+			auditVestingBalances(accs, balances),
+			auditDenomMetadata(bankGenState, stakingGenState.Params.BondDenom),
+		},
+	}
+
+	report.Valid = true
+	for i := range report.Checks {
+		report.Checks[i].Passed = len(report.Checks[i].Issues) == 0
+		report.Valid = report.Valid && report.Checks[i].Passed
+	}
+
+	return report, nil
+}
+
+func auditSupply(bankGenState *banktypes.GenesisState) genesisAuditCheck {
+	check := genesisAuditCheck{Name: auditCheckSupply}
+
+	var total sdk.Coins
+	for _, b := range bankGenState.Balances {
+		total = total.Add(b.Coins...)
+	}
+
+	// bank InitGenesis 는 supply 가 비어 있으면 balances 합으로 채운다.
+	if bankGenState.Supply.Empty() {
+		return check
+	}
+
+	if !coinsEqual(bankGenState.Supply, total) {
+		check.addIssue("supply %s does not equal the sum of balances %s", bankGenState.Supply, total)
+	}
+
+	return check
+}
+
+// auditStakingPools mirrors the pool checks which make staking InitGenesis panic.
+func auditStakingPools(stakingGenState stakingtypes.GenesisState, balances map[string]sdk.Coins) genesisAuditCheck {
+	check := genesisAuditCheck{Name: auditCheckStakingPools}
+	bondDenom := stakingGenState.Params.BondDenom
+
+	bondedTokens := sdk.ZeroInt()
+	notBondedTokens := sdk.ZeroInt()
+	for _, val := range stakingGenState.Validators {
+		switch val.GetStatus() {
+		case stakingtypes.Bonded:
+			bondedTokens = bondedTokens.Add(val.GetTokens())
+		case stakingtypes.Unbonding, stakingtypes.Unbonded:
+			notBondedTokens = notBondedTokens.Add(val.GetTokens())
+		default:
+			check.addIssue("validator %s has invalid status %s", val.OperatorAddress, val.GetStatus())
+		}
+	}
+
+	for _, ubd := range stakingGenState.UnbondingDelegations {
+		for _, entry := range ubd.Entries {
+			notBondedTokens = notBondedTokens.Add(entry.Balance)
+		}
+	}
+
+	// staking genesis 가 없거나 bond denom 이 잘못되면 pool balance 를 비교할 수 없다.
+	if err := sdk.ValidateDenom(bondDenom); err != nil {
+		check.addIssue("invalid staking bond denom %q: %s", bondDenom, err)
+		return check
+	}
+
+	pools := []struct {
+		name   string
+		tokens sdk.Int
+	}{
+		{stakingtypes.BondedPoolName, bondedTokens},
+		{stakingtypes.NotBondedPoolName, notBondedTokens},
+	}
+
+	for _, pool := range pools {
+		addr := authtypes.NewModuleAddress(pool.name).String()
+		expected := sdk.NewCoins(sdk.NewCoin(bondDenom, pool.tokens))
+		if !coinsEqual(balances[addr], expected) {
+			check.addIssue("%s pool balance %s does not match staking tokens %s", pool.name, balances[addr], expected)
+		}
+	}
+
+	return check
+}
+
+func auditGentxBalances(
+	txConfig client.TxEncodingConfig, genutilGenState *genutiltypes.GenesisState,
+	bondDenom string, balances map[string]sdk.Coins,
+) genesisAuditCheck {
+	check := genesisAuditCheck{Name: auditCheckGentxBalances}
+
+	if err := sdk.ValidateDenom(bondDenom); err != nil {
+		if len(genutilGenState.GenTxs) > 0 {
+			check.addIssue("cannot check gentxs against invalid staking bond denom %q", bondDenom)
+		}
+		return check
+	}
+
+	// 같은 delegator 의 gentx 가 여러 개이면 합산해서 본다.
+	delegated := make(map[string]sdk.Coins)
+	for i, bz := range genutilGenState.GenTxs {
+		tx, err := txConfig.TxJSONDecoder()(bz)
+		if err != nil {
+			check.addIssue("gentx %d: %s", i, err)
+			continue
+		}
+
+		for _, msg := range tx.GetMsgs() {
+			createVal, ok := msg.(*stakingtypes.MsgCreateValidator)
+			if !ok {
+				continue
+			}
+
+			if createVal.Value.Denom != bondDenom {
+				check.addIssue("gentx %d: self-delegation denom %s is not the bond denom %s", i, createVal.Value.Denom, bondDenom)
+				continue
+			}
+
+			delegated[createVal.DelegatorAddress] = delegated[createVal.DelegatorAddress].Add(createVal.Value)
+		}
+	}
+
+	for _, delegator := range sortedKeys(delegated) {
+		need := delegated[delegator].AmountOf(bondDenom)
+		have := balances[delegator].AmountOf(bondDenom)
+		if have.LT(need) {
+			check.addIssue("gentx delegator %s has %s%s but delegates %s%s", delegator, have, bondDenom, need, bondDenom)
+		}
+	}
+
+	return check
+}
+
+// auditBlockedAddrs reports regular accounts at module account addresses.
+// Module accounts themselves (e.g. from an export) are allowed.
+func auditBlockedAddrs(accs authtypes.GenesisAccounts) genesisAuditCheck {
+	check := genesisAuditCheck{Name: auditCheckBlockedAddrs}
+	blocked := jscapp.GetModuleAccountAddrs()
+
+	for _, acc := range accs {
+		addr := acc.GetAddress().String()
+		if !blocked[addr] {
+			continue
+		}
+
+		if _, ok := acc.(authtypes.ModuleAccountI); !ok {
+			check.addIssue("genesis account %s is a blocked module account address", addr)
+		}
+	}
+
+	return check
+}
+
+func auditVestingBalances(accs authtypes.GenesisAccounts, balances map[string]sdk.Coins) genesisAuditCheck {
+	check := genesisAuditCheck{Name: auditCheckVesting}
+
+	for _, acc := range accs {
+		vacc, ok := acc.(vestingexported.VestingAccount)
+		if !ok {
+			continue
+		}
+
+		addr := acc.GetAddress().String()
+		if !vacc.GetOriginalVesting().IsAllLTE(balances[addr]) {
+			check.addIssue("vesting account %s vests %s but has balance %s", addr, vacc.GetOriginalVesting(), balances[addr])
+		}
+	}
+
+	return check
+}
+
+func auditDenomMetadata(bankGenState *banktypes.GenesisState, bondDenom string) genesisAuditCheck {
+	check := genesisAuditCheck{Name: auditCheckDenomMetadata}
+
+	var denoms []string
+	seen := make(map[string]bool)
+	addDenom := func(denom string) {
+		if denom != "" && !seen[denom] {
+			seen[denom] = true
+			denoms = append(denoms, denom)
+		}
+	}
+
+	addDenom(bondDenom)
+	for _, coin := range bankGenState.Supply {
+		addDenom(coin.Denom)
+	}
+	for _, b := range bankGenState.Balances {
+		for _, coin := range b.Coins {
+			addDenom(coin.Denom)
+		}
+	}
+	sort.Strings(denoms)
+
+	metadata := make(map[string]bool, len(bankGenState.DenomMetadata))
+	for _, m := range bankGenState.DenomMetadata {
+		metadata[m.Base] = true
+	}
+
+	for _, denom := range denoms {
+		if !metadata[denom] {
+			check.addIssue("no denom metadata for %s", denom)
+		}
+	}
+
+	return check
+}
+
+// coinsEqual compares coins without panicking on different denoms.
+func coinsEqual(a, b sdk.Coins) bool {
+	return a.IsAllGTE(b) && b.IsAllGTE(a)
+}
+
+func sortedKeys(m map[string]sdk.Coins) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,215 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	jsccmd "github.com/Jeongseup/jeongseupchain/jeongseupd/cmd"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authvesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	genutiltest "github.com/cosmos/cosmos-sdk/x/genutil/client/testutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+type auditReport struct {
+	Valid  bool `json:"valid"`
+	Checks []struct {
+		Name   string   `json:"name"`
+		Passed bool     `json:"passed"`
+		Issues []string `json:"issues"`
+	} `json:"checks"`
+}
+
+func TestGenesisAuditCmd(t *testing.T) {
+	encodingConfig := jscapp.MakeEncodingConfig()
+	appCodec := encodingConfig.Marshaler
+
+	_, pubKey, addr := testdata.KeyTestPubAddr()
+	stakeMetadata := banktypes.Metadata{
+		DenomUnits: []*banktypes.DenomUnit{{Denom: "stake", Exponent: 0}},
+		Base:       "stake",
+		Display:    "stake",
+		Name:       "Stake",
+		Symbol:     "STAKE",
+	}
+
+	gentx := func(t *testing.T, amount int64) json.RawMessage {
+		msg, err := stakingtypes.NewMsgCreateValidator(
+			sdk.ValAddress(addr), pubKey, sdk.NewInt64Coin("stake", amount),
+			stakingtypes.NewDescription("validator", "", "", "", ""),
+			stakingtypes.NewCommissionRates(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2)),
+			sdk.OneInt(),
+		)
+		require.NoError(t, err)
+
+		txBuilder := encodingConfig.TxConfig.NewTxBuilder()
+		require.NoError(t, txBuilder.SetMsgs(msg))
+		bz, err := encodingConfig.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+
+		return bz
+	}
+
+	tests := []struct {
+		name       string
+		edit       func(t *testing.T, appState map[string]json.RawMessage)
+		failChecks []string
+	}{
+		{
+			name: "consistent genesis",
+			edit: func(t *testing.T, appState map[string]json.RawMessage) {
+				setAuditAccounts(t, appCodec, appState, authtypes.NewBaseAccountWithAddress(addr))
+				setAuditBank(t, appCodec, appState, func(gs *banktypes.GenesisState) {
+					gs.Balances = []banktypes.Balance{{Address: addr.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))}}
+					gs.Supply = sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))
+					gs.DenomMetadata = []banktypes.Metadata{stakeMetadata}
+				})
+				appState[genutiltypes.ModuleName] = appCodec.MustMarshalJSON(
+					genutiltypes.NewGenesisState([]json.RawMessage{gentx(t, 1000)}))
+			},
+		},
+		{
+			name: "supply and metadata mismatch",
+			edit: func(t *testing.T, appState map[string]json.RawMessage) {
+				setAuditBank(t, appCodec, appState, func(gs *banktypes.GenesisState) {
+					gs.Balances = []banktypes.Balance{{Address: addr.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("atom", 10), sdk.NewInt64Coin("stake", 1000))}}
+					gs.Supply = sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))
+					gs.DenomMetadata = []banktypes.Metadata{stakeMetadata}
+				})
+			},
+			failChecks: []string{"bank_supply", "denom_metadata"},
+		},
+		{
+			name: "unbacked staking pool and gentx",
+			edit: func(t *testing.T, appState map[string]json.RawMessage) {
+				bondedPool := authtypes.NewModuleAddress(stakingtypes.BondedPoolName)
+				setAuditBank(t, appCodec, appState, func(gs *banktypes.GenesisState) {
+					gs.Balances = []banktypes.Balance{
+						{Address: addr.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("stake", 10))},
+						{Address: bondedPool.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("stake", 100))},
+					}
+					gs.DenomMetadata = []banktypes.Metadata{stakeMetadata}
+				})
+				appState[genutiltypes.ModuleName] = appCodec.MustMarshalJSON(
+					genutiltypes.NewGenesisState([]json.RawMessage{gentx(t, 1000)}))
+			},
+			failChecks: []string{"staking_pools", "gentx_balances"},
+		},
+		{
+			name: "blocked address and over-vested account",
+			edit: func(t *testing.T, appState map[string]json.RawMessage) {
+				feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+				vacc := authvesting.NewContinuousVestingAccount(
+					authtypes.NewBaseAccountWithAddress(addr), sdk.NewCoins(sdk.NewInt64Coin("stake", 2000)), 1, 2)
+				setAuditAccounts(t, appCodec, appState, authtypes.NewBaseAccountWithAddress(feeCollector), vacc)
+				setAuditBank(t, appCodec, appState, func(gs *banktypes.GenesisState) {
+					gs.Balances = []banktypes.Balance{{Address: addr.String(), Coins: sdk.NewCoins(sdk.NewInt64Coin("stake", 1000))}}
+					gs.DenomMetadata = []banktypes.Metadata{stakeMetadata}
+				})
+			},
+			failChecks: []string{"blocked_addresses", "vesting_balances"},
+		},
+		{
+			name: "no staking genesis",
+			edit: func(t *testing.T, appState map[string]json.RawMessage) {
+				delete(appState, stakingtypes.ModuleName)
+			},
+			failChecks: []string{"staking_pools"},
+		},
+		{
+			name: "empty bond denom",
+			edit: func(t *testing.T, appState map[string]json.RawMessage) {
+				var stakingGenState stakingtypes.GenesisState
+				appCodec.MustUnmarshalJSON(appState[stakingtypes.ModuleName], &stakingGenState)
+				stakingGenState.Params.BondDenom = ""
+				appState[stakingtypes.ModuleName] = appCodec.MustMarshalJSON(&stakingGenState)
+				appState[genutiltypes.ModuleName] = appCodec.MustMarshalJSON(
+					genutiltypes.NewGenesisState([]json.RawMessage{gentx(t, 1000)}))
+			},
+			failChecks: []string{"staking_pools", "gentx_balances"},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			home := t.TempDir()
+			cfg, err := genutiltest.CreateDefaultTendermintConfig(home)
+			require.NoError(t, err)
+			require.NoError(t, genutiltest.ExecInitCmd(jscapp.ModuleBasics, home, appCodec))
+
+			appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(cfg.GenesisFile())
+			require.NoError(t, err)
+			tc.edit(t, appState)
+			genDoc.AppState, err = json.Marshal(appState)
+			require.NoError(t, err)
+			require.NoError(t, genutil.ExportGenesisFile(genDoc, cfg.GenesisFile()))
+
+			serverCtx := server.NewContext(viper.New(), cfg, log.NewNopLogger())
+			clientCtx := client.Context{}.
+				WithCodec(appCodec).
+				WithTxConfig(encodingConfig.TxConfig).
+				WithHomeDir(home)
+
+			ctx := context.Background()
+			ctx = context.WithValue(ctx, client.ClientContextKey, &clientCtx)
+			ctx = context.WithValue(ctx, server.ServerContextKey, serverCtx)
+
+			out := new(bytes.Buffer)
+			cmd := jsccmd.GenesisCmd(jscapp.ModuleBasics, home)
+			cmd.SetOut(out)
+			cmd.SetArgs([]string{"audit"})
+			err = cmd.ExecuteContext(ctx)
+
+			var report auditReport
+			require.NoError(t, json.Unmarshal(out.Bytes(), &report), out.String())
+
+			failed := []string{}
+			for _, check := range report.Checks {
+				if !check.Passed {
+					require.NotEmpty(t, check.Issues)
+					failed = append(failed, check.Name)
+				}
+			}
+
+			if len(tc.failChecks) == 0 {
+				require.NoError(t, err)
+				require.True(t, report.Valid)
+				require.Empty(t, failed)
+			} else {
+				require.Error(t, err)
+				require.False(t, report.Valid)
+				require.ElementsMatch(t, tc.failChecks, failed)
+			}
+		})
+	}
+}
+
+func setAuditAccounts(t *testing.T, cdc codec.Codec, appState map[string]json.RawMessage, accs ...authtypes.GenesisAccount) {
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, appState)
+	packed, err := authtypes.PackAccounts(accs)
+	require.NoError(t, err)
+	authGenState.Accounts = packed
+	appState[authtypes.ModuleName] = cdc.MustMarshalJSON(&authGenState)
+}
+
+func setAuditBank(t *testing.T, cdc codec.Codec, appState map[string]json.RawMessage, edit func(*banktypes.GenesisState)) {
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, appState)
+	edit(bankGenState)
+	appState[banktypes.ModuleName] = cdc.MustMarshalJSON(bankGenState)
+}
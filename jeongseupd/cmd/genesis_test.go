@@ -0,0 +1,180 @@
+package cmd_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	jsccmd "github.com/Jeongseup/jeongseupchain/jeongseupd/cmd"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/testutil/testdata"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	genutiltest "github.com/cosmos/cosmos-sdk/x/genutil/client/testutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func TestGenesisSetCmd(t *testing.T) {
+	home := t.TempDir()
+	cfg, err := genutiltest.CreateDefaultTendermintConfig(home)
+	require.NoError(t, err)
+
+	encodingConfig := jscapp.MakeEncodingConfig()
+	appCodec := encodingConfig.Marshaler
+	require.NoError(t, genutiltest.ExecInitCmd(jscapp.ModuleBasics, home, appCodec))
+
+	serverCtx := server.NewContext(viper.New(), cfg, log.NewNopLogger())
+	clientCtx := client.Context{}.
+		WithCodec(appCodec).
+		WithTxConfig(encodingConfig.TxConfig).
+		WithHomeDir(home)
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, client.ClientContextKey, &clientCtx)
+	ctx = context.WithValue(ctx, server.ServerContextKey, serverCtx)
+
+	set := func(args ...string) error {
+		cmd := jsccmd.GenesisCmd(jscapp.ModuleBasics, home)
+		cmd.SetArgs(append([]string{"set"}, args...))
+		return cmd.ExecuteContext(ctx)
+	}
+
+	require.NoError(t, set("staking", "bond-denom", "ujeong"))
+	require.NoError(t, set("staking", "unbonding-time", "72h"))
+	require.NoError(t, set("staking", "max-validators", "50"))
+	require.NoError(t, set("consensus", "block.max_gas", "50000000"))
+	require.NoError(t, set("bank", "denom-metadata", `{
+		"description": "The native staking token of the Jeongseup Chain",
+		"denom_units": [
+			{"denom": "ujeong", "exponent": 0, "aliases": ["microjeong"]},
+			{"denom": "jeong", "exponent": 6}
+		],
+		"base": "ujeong",
+		"display": "jeong",
+		"name": "Jeong",
+		"symbol": "JEONG"
+	}`))
+
+	// invalid edits must not be written
+	require.Error(t, set("staking", "bond-denom", "!nvalid"))
+	require.Error(t, set("staking", "max-validators", "0"))
+	require.Error(t, set("consensus", "block.max_bytes", "0"))
+	require.Error(t, set("staking", "unknown-key", "1"))
+	require.Error(t, set("bank", "denom-metadata", `{"base": "ujeong", "display": "jeong"}`))
+
+	appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(cfg.GenesisFile())
+	require.NoError(t, err)
+
+	var stakingGenState stakingtypes.GenesisState
+	appCodec.MustUnmarshalJSON(appState[stakingtypes.ModuleName], &stakingGenState)
+	require.Equal(t, "ujeong", stakingGenState.Params.BondDenom)
+	require.Equal(t, 72*time.Hour, stakingGenState.Params.UnbondingTime)
+	require.Equal(t, uint32(50), stakingGenState.Params.MaxValidators)
+
+	// the denoms which used the old bond denom follow it
+	var mintGenState minttypes.GenesisState
+	appCodec.MustUnmarshalJSON(appState[minttypes.ModuleName], &mintGenState)
+	require.Equal(t, "ujeong", mintGenState.Params.MintDenom)
+
+	var govGenState govtypes.GenesisState
+	appCodec.MustUnmarshalJSON(appState[govtypes.ModuleName], &govGenState)
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin("ujeong", govtypes.DefaultMinDepositTokens)), govGenState.DepositParams.MinDeposit)
+
+	var crisisGenState crisistypes.GenesisState
+	appCodec.MustUnmarshalJSON(appState[crisistypes.ModuleName], &crisisGenState)
+	require.Equal(t, "ujeong", crisisGenState.ConstantFee.Denom)
+
+	bankGenState := banktypes.GetGenesisStateFromAppState(appCodec, appState)
+	require.Len(t, bankGenState.DenomMetadata, 1)
+	require.Equal(t, "JEONG", bankGenState.DenomMetadata[0].Symbol)
+
+	require.Equal(t, int64(50000000), genDoc.ConsensusParams.Block.MaxGas)
+	require.Equal(t, tmtypes.DefaultConsensusParams().Block.MaxBytes, genDoc.ConsensusParams.Block.MaxBytes)
+
+	// collect-gentxs 이후에는 gentx 의 self-delegation denom 이 고정되므로 바꿀 수 없다.
+	_, pubKey, addr := testdata.KeyTestPubAddr()
+	createVal, err := stakingtypes.NewMsgCreateValidator(
+		sdk.ValAddress(addr), pubKey, sdk.NewInt64Coin("ujeong", 1000),
+		stakingtypes.NewDescription("validator", "", "", "", ""),
+		stakingtypes.NewCommissionRates(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2)),
+		sdk.OneInt(),
+	)
+	require.NoError(t, err)
+	txBuilder := encodingConfig.TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(createVal))
+	gentx, err := encodingConfig.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+
+	appState[genutiltypes.ModuleName] = appCodec.MustMarshalJSON(
+		genutiltypes.NewGenesisState([]json.RawMessage{gentx}))
+	genDoc.AppState, err = json.Marshal(appState)
+	require.NoError(t, err)
+	require.NoError(t, genutil.ExportGenesisFile(genDoc, cfg.GenesisFile()))
+
+	err = set("staking", "bond-denom", "uother")
+	require.ErrorContains(t, err, "1 gentxs self-delegating ujeong")
+	require.NoError(t, set("staking", "bond-denom", "ujeong"))
+
+	appState, _, err = genutiltypes.GenesisStateFromGenFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	appCodec.MustUnmarshalJSON(appState[stakingtypes.ModuleName], &stakingGenState)
+	require.Equal(t, "ujeong", stakingGenState.Params.BondDenom)
+}
+
+func TestGenesisSetCmdDenomMetadataFile(t *testing.T) {
+	home := t.TempDir()
+	cfg, err := genutiltest.CreateDefaultTendermintConfig(home)
+	require.NoError(t, err)
+
+	encodingConfig := jscapp.MakeEncodingConfig()
+	appCodec := encodingConfig.Marshaler
+	require.NoError(t, genutiltest.ExecInitCmd(jscapp.ModuleBasics, home, appCodec))
+
+	serverCtx := server.NewContext(viper.New(), cfg, log.NewNopLogger())
+	clientCtx := client.Context{}.
+		WithCodec(appCodec).
+		WithTxConfig(encodingConfig.TxConfig).
+		WithHomeDir(home)
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, client.ClientContextKey, &clientCtx)
+	ctx = context.WithValue(ctx, server.ServerContextKey, serverCtx)
+
+	metadataFile := filepath.Join(t.TempDir(), "metadata.json")
+	require.NoError(t, os.WriteFile(metadataFile, []byte(`{
+		"denom_units": [{"denom": "stake", "exponent": 0}],
+		"base": "stake",
+		"display": "stake",
+		"name": "Stake",
+		"symbol": "STAKE"
+	}`), 0o600))
+
+	for i := 0; i < 2; i++ {
+		cmd := jsccmd.GenesisCmd(jscapp.ModuleBasics, home)
+		cmd.SetArgs([]string{"set", "bank", "denom-metadata", metadataFile})
+		require.NoError(t, cmd.ExecuteContext(ctx))
+	}
+
+	appState, _, err := genutiltypes.GenesisStateFromGenFile(cfg.GenesisFile())
+	require.NoError(t, err)
+
+	// 같은 base denom 은 교체되므로 한 번만 들어간다.
+	bankGenState := banktypes.GetGenesisStateFromAppState(appCodec, appState)
+	require.Len(t, bankGenState.DenomMetadata, 1)
+	require.Equal(t, "stake", bankGenState.DenomMetadata[0].Base)
+}
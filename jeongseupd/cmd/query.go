@@ -4,6 +4,8 @@ import (
 	jsapp "github.com/Jeongseup/jeongseupchain/app"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/rpc"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 
 	"github.com/spf13/cobra"
 )
@@ -17,15 +19,18 @@ func queryCommand() *cobra.Command {
 		SuggestionsMinimumDistance: 2,
 		RunE:                       client.ValidateCmd,
 	}
-	// cmd.AddCommand(
-	// 	authcmd.GetAccountCmd(),
-	// 	rpc.ValidatorCommand(),
-	// 	rpc.BlockCommand(),
-	// 	authcmd.QueryTxsByEventsCmd(),
-	// 	authcmd.QueryTxCmd(),
-	// )
 
-	jsapp.ModuleBasics.AddTxCommands(cmd)
+	// auth, rpc 기본 query 커맨드
+	cmd.AddCommand(
+		authcmd.GetAccountCmd(),
+		rpc.ValidatorCommand(),
+		rpc.BlockCommand(),
+		authcmd.QueryTxsByEventsCmd(),
+		authcmd.QueryTxCmd(),
+	)
+
+	// ModuleBasics에 등록된 모듈들의 query 커맨드 (bank balances, staking validators, ...)
+	jsapp.ModuleBasics.AddQueryCommands(cmd)
 	cmd.PersistentFlags().String(flags.FlagChainID, "", "The network chain ID")
 
 	return cmd
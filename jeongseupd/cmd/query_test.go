@@ -0,0 +1,37 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	jsccmd "github.com/Jeongseup/jeongseupchain/jeongseupd/cmd"
+)
+
+func TestQueryCmdRegistersModuleQueries(t *testing.T) {
+	rootCmd, _ := jsccmd.NewRootCmd()
+	queryCmd, _, err := rootCmd.Find([]string{"query"})
+	require.NoError(t, err)
+	require.Equal(t, "query", queryCmd.Name())
+
+	registered := make(map[string]bool)
+	for _, sub := range queryCmd.Commands() {
+		registered[sub.Name()] = true
+	}
+
+	// auth, rpc 기본 커맨드
+	for _, name := range []string{"account", "tendermint-validator-set", "block", "txs", "tx"} {
+		require.True(t, registered[name], "missing built-in query command %q", name)
+	}
+
+	// query 커맨드가 있는 모듈은 모두 query root가 등록되어 있어야 함
+	for moduleName, basic := range jscapp.ModuleBasics {
+		moduleQueryCmd := basic.GetQueryCmd()
+		if moduleQueryCmd == nil {
+			continue
+		}
+
+		require.True(t, registered[moduleQueryCmd.Name()], "module %s query root %q is not registered", moduleName, moduleQueryCmd.Name())
+	}
+}
@@ -13,10 +13,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 	serverconfig "github.com/cosmos/cosmos-sdk/server/config"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	genutilcli "github.com/cosmos/cosmos-sdk/x/genutil/client/cli"
 	"github.com/spf13/cobra"
+	tmcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	tmcli "github.com/tendermint/tendermint/libs/cli"
 
 	jscapp "github.com/Jeongseup/jeongseupchain/app"
@@ -104,6 +106,11 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 		genutilcli.GenTxCmd(jscapp.ModuleBasics, encodingConfig.TxConfig, banktypes.GenesisBalancesIterator{}, jscapp.DefaultNodeHome),
 		genutilcli.ValidateGenesisCmd(jscapp.ModuleBasics),
 		AddGenesisAccountCmd(jscapp.DefaultNodeHome),
+		AddGenesisAccountsBulkCmd(jscapp.DefaultNodeHome),
+		// genesis.json 편집 (jq 대신)
+		GenesisCmd(jscapp.ModuleBasics, jscapp.DefaultNodeHome),
+		// 로컬 multi validator 네트워크
+		TestnetCmd(jscapp.ModuleBasics, banktypes.GenesisBalancesIterator{}),
 
 		tmcli.NewCompletionCmd(rootCmd, true),
 		debug.Cmd(),
@@ -113,7 +120,7 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 	ac := appCreator{
 		encCfg: encodingConfig,
 	}
-	server.AddCommands(rootCmd, jscapp.DefaultNodeHome, ac.newApp, ac.appExport, addModuleInitFlags)
+	addServerCommands(rootCmd, ac)
 
 	// add keybase, auxiliary RPC, query, and tx child commands
 	rootCmd.AddCommand(
@@ -124,6 +131,35 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 	)
 }
 
+// addServerCommands adds the commands of server.AddCommands, except that
+// export is our ExportCmd with module selection and streaming.
+func addServerCommands(rootCmd *cobra.Command, ac appCreator) {
+	tendermintCmd := &cobra.Command{
+		Use:   "tendermint",
+		Short: "Tendermint subcommands",
+	}
+
+	tendermintCmd.AddCommand(
+		server.ShowNodeIDCmd(),
+		server.ShowValidatorCmd(),
+		server.ShowAddressCmd(),
+		server.VersionCmd(),
+		tmcmd.ResetAllCmd,
+		tmcmd.ResetStateCmd,
+	)
+
+	startCmd := server.StartCmd(ac.newApp, jscapp.DefaultNodeHome)
+	addModuleInitFlags(startCmd)
+
+	rootCmd.AddCommand(
+		startCmd,
+		tendermintCmd,
+		ExportCmd(jscapp.DefaultNodeHome),
+		version.NewVersionCommand(),
+		server.NewRollbackCmd(jscapp.DefaultNodeHome),
+	)
+}
+
 func addModuleInitFlags(startCmd *cobra.Command) {
 	crisis.AddModuleInitFlags(startCmd)
 }
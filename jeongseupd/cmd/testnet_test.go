@@ -0,0 +1,98 @@
+package cmd_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	jsccmd "github.com/Jeongseup/jeongseupchain/jeongseupd/cmd"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	genutiltest "github.com/cosmos/cosmos-sdk/x/genutil/client/testutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+func TestTestnetCmd(t *testing.T) {
+	home := t.TempDir()
+	outputDir := t.TempDir()
+	encCfg := jscapp.MakeEncodingConfig()
+
+	cfg, err := genutiltest.CreateDefaultTendermintConfig(home)
+	require.NoError(t, err)
+
+	serverCtx := server.NewContext(viper.New(), cfg, log.NewNopLogger())
+	clientCtx := client.Context{}.
+		WithCodec(encCfg.Marshaler).
+		WithInterfaceRegistry(encCfg.InterfaceRegistry).
+		WithTxConfig(encCfg.TxConfig).
+		WithLegacyAmino(encCfg.Amino).
+		WithHomeDir(home)
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, client.ClientContextKey, &clientCtx)
+	ctx = context.WithValue(ctx, server.ServerContextKey, serverCtx)
+
+	cmd := jsccmd.TestnetCmd(jscapp.ModuleBasics, banktypes.GenesisBalancesIterator{})
+	cmd.SetArgs([]string{
+		"--v=2",
+		fmt.Sprintf("--output-dir=%s", outputDir),
+		fmt.Sprintf("--%s=testnet-1", flags.FlagChainID),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringBackend, keyring.BackendTest),
+	})
+	require.NoError(t, cmd.ExecuteContext(ctx))
+
+	var genesisFiles []string
+	for i := 0; i < 2; i++ {
+		nodeHome := filepath.Join(outputDir, fmt.Sprintf("node%d", i), "jeongseupd")
+		genesisFiles = append(genesisFiles, filepath.Join(nodeHome, "config", "genesis.json"))
+
+		// 노드마다 포트가 달라야 하고 나머지 노드를 peer로 가지고 있어야 함
+		configToml, err := os.ReadFile(filepath.Join(nodeHome, "config", "config.toml"))
+		require.NoError(t, err)
+		require.Contains(t, string(configToml), fmt.Sprintf(`laddr = "tcp://0.0.0.0:%d"`, 26656+i*100))
+		require.Contains(t, string(configToml), fmt.Sprintf(`laddr = "tcp://0.0.0.0:%d"`, 26657+i*100))
+		require.Contains(t, string(configToml), fmt.Sprintf(`127.0.0.1:%d`, 26656+(1-i)*100))
+		require.NotContains(t, string(configToml), `persistent_peers = ""`)
+
+		_, err = os.Stat(filepath.Join(nodeHome, "config", "app.toml"))
+		require.NoError(t, err)
+	}
+
+	appState, genDoc, err := genutiltypes.GenesisStateFromGenFile(genesisFiles[0])
+	require.NoError(t, err)
+	require.Equal(t, "testnet-1", genDoc.ChainID)
+	require.NoError(t, jscapp.ModuleBasics.ValidateGenesis(encCfg.Marshaler, encCfg.TxConfig, appState))
+
+	genutilGenState := genutiltypes.GetGenesisStateFromAppState(encCfg.Marshaler, appState)
+	require.Len(t, genutilGenState.GenTxs, 2)
+
+	// validator 는 delegator 에게도 reward 가 가도록 simapp 과 같은 기본 commission 을 쓴다.
+	for _, genTx := range genutilGenState.GenTxs {
+		tx, err := encCfg.TxConfig.TxJSONDecoder()(genTx)
+		require.NoError(t, err)
+		msg, ok := tx.GetMsgs()[0].(*stakingtypes.MsgCreateValidator)
+		require.True(t, ok)
+		require.Equal(t, stakingtypes.NewCommissionRates(
+			sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(1, 2),
+		), msg.Commission)
+	}
+
+	// 모든 노드가 같은 genesis를 가져야 함
+	otherGenesis, err := os.ReadFile(genesisFiles[1])
+	require.NoError(t, err)
+	firstGenesis, err := os.ReadFile(genesisFiles[0])
+	require.NoError(t, err)
+	require.Equal(t, firstGenesis, otherGenesis)
+}
@@ -4,6 +4,7 @@ import (
 	jsapp "github.com/Jeongseup/jeongseupchain/app"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 
 	"github.com/spf13/cobra"
 )
@@ -17,16 +18,17 @@ func txCommand() *cobra.Command {
 		RunE:                       client.ValidateCmd,
 	}
 
+	// offline signing: --generate-only로 만든 tx를 air-gapped 머신에서 sign 하고 나중에 broadcast
 	cmd.AddCommand(
-	// authcmd.GetSignCommand(),
-	// authcmd.GetSignBatchCommand(),
-	// authcmd.GetMultiSignCommand(),
-	// authcmd.GetMultiSignBatchCmd(),
-	// authcmd.GetValidateSignaturesCommand(),
-	// flags.LineBreak,
-	// authcmd.GetBroadcastCommand(),
-	// authcmd.GetEncodeCommand(),
-	// authcmd.GetDecodeCommand(),
+		authcmd.GetSignCommand(),
+		authcmd.GetSignBatchCommand(),
+		authcmd.GetMultiSignCommand(),
+		authcmd.GetMultiSignBatchCmd(),
+		authcmd.GetValidateSignaturesCommand(),
+		flags.LineBreak,
+		authcmd.GetBroadcastCommand(),
+		authcmd.GetEncodeCommand(),
+		authcmd.GetDecodeCommand(),
 	)
 
 	jsapp.ModuleBasics.AddTxCommands(cmd)
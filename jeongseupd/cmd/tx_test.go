@@ -0,0 +1,80 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	jsccmd "github.com/Jeongseup/jeongseupchain/jeongseupd/cmd"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+)
+
+func TestTxCmdRegistersOfflineSigningCommands(t *testing.T) {
+	rootCmd, _ := jsccmd.NewRootCmd()
+	txCmd, _, err := rootCmd.Find([]string{"tx"})
+	require.NoError(t, err)
+	require.Equal(t, "tx", txCmd.Name())
+
+	registered := make(map[string]bool)
+	for _, sub := range txCmd.Commands() {
+		registered[sub.Name()] = true
+	}
+
+	for _, name := range []string{
+		"sign", "sign-batch", "multisign", "multisign-batch", "validate-signatures",
+		"broadcast", "encode", "decode",
+	} {
+		require.True(t, registered[name], "missing offline signing command %q", name)
+	}
+}
+
+func TestTxVestingCreateVestingAccountCmd(t *testing.T) {
+	home := t.TempDir()
+	from := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	to := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	rootCmd, encCfg := jsccmd.NewRootCmd()
+	out := new(bytes.Buffer)
+	captureClientOutput(rootCmd, out)
+	rootCmd.SetArgs([]string{
+		"tx", "vesting", "create-vesting-account", to.String(), "1000stake", "1700003600",
+		fmt.Sprintf("--%s=%s", flags.FlagFrom, from),
+		fmt.Sprintf("--%s=true", flags.FlagGenerateOnly),
+		fmt.Sprintf("--%s=test-chain", flags.FlagChainID),
+		fmt.Sprintf("--%s=%s", flags.FlagHome, home),
+	})
+	require.NoError(t, svrcmd.Execute(rootCmd, home))
+
+	tx, err := encCfg.TxConfig.TxJSONDecoder()(out.Bytes())
+	require.NoError(t, err, out.String())
+	require.Len(t, tx.GetMsgs(), 1)
+
+	msg, ok := tx.GetMsgs()[0].(*vestingtypes.MsgCreateVestingAccount)
+	require.True(t, ok, "expected MsgCreateVestingAccount, got %T", tx.GetMsgs()[0])
+	require.Equal(t, from.String(), msg.FromAddress)
+	require.Equal(t, to.String(), msg.ToAddress)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1000)), msg.Amount)
+	require.Equal(t, int64(1700003600), msg.EndTime)
+	require.False(t, msg.Delayed)
+}
+
+// captureClientOutput makes the client context of rootCmd print to out. The
+// root command builds its own client context, which prints to stdout.
+func captureClientOutput(rootCmd *cobra.Command, out *bytes.Buffer) {
+	preRun := rootCmd.PersistentPreRunE
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := preRun(cmd, args); err != nil {
+			return err
+		}
+
+		return client.SetCmdClientContext(cmd, client.GetClientContextFromCmd(cmd).WithOutput(out))
+	}
+}
@@ -0,0 +1,86 @@
+package network
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil/network"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	dbm "github.com/tendermint/tm-db"
+
+	jscapp "github.com/Jeongseup/jeongseupchain/app"
+	"github.com/Jeongseup/jeongseupchain/app/helpers"
+	jscparams "github.com/Jeongseup/jeongseupchain/app/params"
+)
+
+// New creates an in-process network of JeongseupApp validators for integration
+// tests. DefaultConfig is used unless a config is provided, and the network is
+// cleaned up when the test finishes.
+func New(t *testing.T, configs ...network.Config) *network.Network {
+	if len(configs) > 1 {
+		panic("at most one config should be provided")
+	}
+
+	var cfg network.Config
+	if len(configs) == 0 {
+		cfg = DefaultConfig()
+	} else {
+		cfg = configs[0]
+	}
+
+	net := network.New(t, cfg)
+	t.Cleanup(net.Cleanup)
+
+	return net
+}
+
+// NewAppConstructor returns an AppConstructor which starts a JeongseupApp on
+// an in-memory database for every validator of the network.
+func NewAppConstructor(encodingCfg jscparams.EncodingConfig) network.AppConstructor {
+	return func(val network.Validator) servertypes.Application {
+		return jscapp.NewJeongseupApp(
+			val.Ctx.Logger, dbm.NewMemDB(), nil, true, make(map[int64]bool), val.Ctx.Config.RootDir, 0,
+			encodingCfg,
+			helpers.EmptyAppOptions{},
+			baseapp.SetPruning(storetypes.NewPruningOptionsFromString(val.AppConfig.Pruning)),
+			baseapp.SetMinGasPrices(val.AppConfig.MinGasPrices),
+		)
+	}
+}
+
+// DefaultConfig returns a network config built from the JeongseupApp module
+// basics and encoding config. Every validator gets its own gRPC, API and RPC
+// address on a random local port.
+func DefaultConfig() network.Config {
+	encCfg := jscapp.MakeEncodingConfig()
+
+	return network.Config{
+		Codec:             encCfg.Marshaler,
+		TxConfig:          encCfg.TxConfig,
+		LegacyAmino:       encCfg.Amino,
+		InterfaceRegistry: encCfg.InterfaceRegistry,
+		AccountRetriever:  authtypes.AccountRetriever{},
+		AppConstructor:    NewAppConstructor(encCfg),
+		GenesisState:      jscapp.ModuleBasics.DefaultGenesis(encCfg.Marshaler),
+		TimeoutCommit:     2 * time.Second,
+		ChainID:           "chain-" + tmrand.NewRand().Str(6),
+		NumValidators:     1,
+		BondDenom:         sdk.DefaultBondDenom,
+		MinGasPrices:      fmt.Sprintf("0.000006%s", sdk.DefaultBondDenom),
+		AccountTokens:     sdk.TokensFromConsensusPower(1000, sdk.DefaultPowerReduction),
+		StakingTokens:     sdk.TokensFromConsensusPower(500, sdk.DefaultPowerReduction),
+		BondedTokens:      sdk.TokensFromConsensusPower(100, sdk.DefaultPowerReduction),
+		PruningStrategy:   storetypes.PruningOptionNothing,
+		CleanupDir:        true,
+		SigningAlgo:       string(hd.Secp256k1Type),
+		KeyringOptions:    []keyring.Option{},
+	}
+}
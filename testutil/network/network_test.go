@@ -0,0 +1,317 @@
+package network_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
+	clitestutil "github.com/cosmos/cosmos-sdk/testutil/cli"
+	"github.com/cosmos/cosmos-sdk/testutil/network"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankcli "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/suite"
+	tmcli "github.com/tendermint/tendermint/libs/cli"
+
+	jsccmd "github.com/Jeongseup/jeongseupchain/jeongseupd/cmd"
+	jscnetwork "github.com/Jeongseup/jeongseupchain/testutil/network"
+)
+
+type IntegrationTestSuite struct {
+	suite.Suite
+
+	cfg     network.Config
+	network *network.Network
+}
+
+func (s *IntegrationTestSuite) SetupSuite() {
+	s.T().Log("setting up integration test suite")
+
+	s.cfg = jscnetwork.DefaultConfig()
+	s.cfg.NumValidators = 2
+	s.network = jscnetwork.New(s.T(), s.cfg)
+
+	_, err := s.network.WaitForHeight(1)
+	s.Require().NoError(err)
+}
+
+func (s *IntegrationTestSuite) TestBankSendAndQueryBalances() {
+	val := s.network.Validators[0]
+	receiver := s.network.Validators[1]
+	clientCtx := val.ClientCtx
+
+	// tx bank send
+	amount := sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10)))
+	out, err := clitestutil.ExecTestCLICmd(clientCtx, bankcli.NewSendTxCmd(), []string{
+		val.Address.String(),
+		receiver.Address.String(),
+		amount.String(),
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10))).String()),
+	})
+	s.Require().NoError(err)
+
+	var txRes sdk.TxResponse
+	s.Require().NoError(clientCtx.Codec.UnmarshalJSON(out.Bytes(), &txRes), out.String())
+	s.Require().Equal(uint32(0), txRes.Code, txRes.RawLog)
+
+	// query bank balances
+	out, err = clitestutil.ExecTestCLICmd(clientCtx, bankcli.GetBalancesCmd(), []string{
+		receiver.Address.String(),
+		fmt.Sprintf("--%s=json", tmcli.OutputFlag),
+	})
+	s.Require().NoError(err)
+
+	var balances banktypes.QueryAllBalancesResponse
+	s.Require().NoError(clientCtx.Codec.UnmarshalJSON(out.Bytes(), &balances), out.String())
+
+	// 받는 쪽 validator는 staking 하고 남은 토큰 + 보낸 만큼 가지고 있어야 함
+	expected := s.cfg.StakingTokens.Sub(s.cfg.BondedTokens).Add(sdk.NewInt(10))
+	s.Require().Equal(expected, balances.Balances.AmountOf(s.cfg.BondDenom))
+}
+
+// TestRootCmdSendAndQuery runs the txs and queries through the command tree of
+// jeongseupd, against the in-process validators.
+func (s *IntegrationTestSuite) TestRootCmdSendAndQuery() {
+	val := s.network.Validators[0]
+	receiver := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	amount := sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(25)))
+
+	out, err := s.execRootCmd(val,
+		"tx", "bank", "send", val.Moniker, receiver.String(), amount.String(),
+		fmt.Sprintf("--%s=%s", flags.FlagChainID, s.cfg.ChainID),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringBackend, keyring.BackendTest),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringDir, val.ClientCtx.KeyringDir),
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10))).String()),
+		fmt.Sprintf("--%s=json", tmcli.OutputFlag),
+	)
+	s.Require().NoError(err)
+
+	var txRes sdk.TxResponse
+	s.Require().NoError(val.ClientCtx.Codec.UnmarshalJSON(out.Bytes(), &txRes), out.String())
+	s.Require().Equal(uint32(0), txRes.Code, txRes.RawLog)
+
+	out, err = s.execRootCmd(val,
+		"query", "bank", "balances", receiver.String(),
+		fmt.Sprintf("--%s=json", tmcli.OutputFlag),
+	)
+	s.Require().NoError(err)
+
+	var balances banktypes.QueryAllBalancesResponse
+	s.Require().NoError(val.ClientCtx.Codec.UnmarshalJSON(out.Bytes(), &balances), out.String())
+	s.Require().Equal(amount, balances.Balances)
+}
+
+// TestOfflineSigning runs the air-gapped workflow through the jeongseupd tx
+// commands: generate-only online, sign offline with the account number and
+// sequence, validate, encode/decode and broadcast the signed tx.
+func (s *IntegrationTestSuite) TestOfflineSigning() {
+	val := s.network.Validators[0]
+	receiver := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	amount := sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(7)))
+	dir := s.T().TempDir()
+
+	unsignedFile := s.generateSendTx(val, val.Address, receiver, amount, dir)
+
+	accNum, seq, err := val.ClientCtx.AccountRetriever.GetAccountNumberSequence(val.ClientCtx, val.Address)
+	s.Require().NoError(err)
+
+	signedFile := filepath.Join(dir, "signed.json")
+	_, err = s.execRootCmd(val, append([]string{
+		"tx", "sign", unsignedFile,
+		fmt.Sprintf("--%s=%s", flags.FlagFrom, val.Moniker),
+		fmt.Sprintf("--%s=true", flags.FlagOffline),
+		fmt.Sprintf("--%s=%d", flags.FlagAccountNumber, accNum),
+		fmt.Sprintf("--%s=%d", flags.FlagSequence, seq),
+		fmt.Sprintf("--%s=%s", flags.FlagOutputDocument, signedFile),
+	}, s.keyringFlags(val)...)...)
+	s.Require().NoError(err)
+
+	out, err := s.execRootCmd(val, "tx", "validate-signatures", signedFile,
+		fmt.Sprintf("--%s=%s", flags.FlagChainID, s.cfg.ChainID),
+	)
+	s.Require().NoError(err, out.String())
+	s.Require().Contains(out.String(), fmt.Sprintf("%s\t\t\t[OK]", val.Address))
+
+	// encode 한 base64 를 decode 하면 sign 한 tx 와 같아야 한다.
+	out, err = s.execRootCmd(val, "tx", "encode", signedFile)
+	s.Require().NoError(err)
+	encoded := strings.TrimSpace(out.String())
+
+	out, err = s.execRootCmd(val, "tx", "decode", encoded)
+	s.Require().NoError(err)
+	signed, err := os.ReadFile(signedFile)
+	s.Require().NoError(err)
+	s.Require().JSONEq(string(signed), out.String())
+
+	s.broadcastTx(val, signedFile)
+	s.requireBalances(val, receiver, amount)
+}
+
+// TestOfflineMultisign signs a send of a 2-of-2 multisig account, like the
+// treasury, with both keys offline and broadcasts the combined tx.
+func (s *IntegrationTestSuite) TestOfflineMultisign() {
+	val := s.network.Validators[0]
+	receiver := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	dir := s.T().TempDir()
+
+	kr := val.ClientCtx.Keyring
+	signers := []string{"treasury-signer-1", "treasury-signer-2"}
+	pubKeys := make([]cryptotypes.PubKey, len(signers))
+	for i, name := range signers {
+		info, _, err := kr.NewMnemonic(name, keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+		s.Require().NoError(err)
+		pubKeys[i] = info.GetPubKey()
+	}
+	multisigInfo, err := kr.SaveMultisig("treasury", kmultisig.NewLegacyAminoPubKey(2, pubKeys))
+	s.Require().NoError(err)
+	multisigAddr := multisigInfo.GetAddress()
+
+	// fund the multisig account, which also creates it on chain
+	out, err := s.execRootCmd(val, append([]string{
+		"tx", "bank", "send", val.Moniker, multisigAddr.String(), sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(1000))).String(),
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10))).String()),
+		fmt.Sprintf("--%s=json", tmcli.OutputFlag),
+	}, s.keyringFlags(val)...)...)
+	s.Require().NoError(err)
+	var txRes sdk.TxResponse
+	s.Require().NoError(val.ClientCtx.Codec.UnmarshalJSON(out.Bytes(), &txRes), out.String())
+	s.Require().Equal(uint32(0), txRes.Code, txRes.RawLog)
+
+	amount := sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(100)))
+	unsignedFile := s.generateSendTx(val, multisigAddr, receiver, amount, dir)
+
+	accNum, seq, err := val.ClientCtx.AccountRetriever.GetAccountNumberSequence(val.ClientCtx, multisigAddr)
+	s.Require().NoError(err)
+	offlineFlags := append([]string{
+		fmt.Sprintf("--%s=true", flags.FlagOffline),
+		fmt.Sprintf("--%s=%d", flags.FlagAccountNumber, accNum),
+		fmt.Sprintf("--%s=%d", flags.FlagSequence, seq),
+	}, s.keyringFlags(val)...)
+
+	// 각 signer 는 자기 머신에서 signature 만 만든다.
+	sigFiles := make([]string, len(signers))
+	for i, name := range signers {
+		sigFiles[i] = filepath.Join(dir, name+".json")
+		_, err = s.execRootCmd(val, append([]string{
+			"tx", "sign", unsignedFile,
+			fmt.Sprintf("--%s=%s", flags.FlagFrom, name),
+			fmt.Sprintf("--multisig=%s", multisigAddr),
+			fmt.Sprintf("--%s=%s", flags.FlagOutputDocument, sigFiles[i]),
+		}, offlineFlags...)...)
+		s.Require().NoError(err)
+	}
+
+	// v0.45 multisign 은 --output-document 를 열기만 하고 tx 는 stdout 에 쓰므로 출력을 저장한다.
+	out, err = s.execRootCmd(val, append(append([]string{"tx", "multisign", unsignedFile, "treasury"}, sigFiles...), offlineFlags...)...)
+	s.Require().NoError(err)
+	signedFile := filepath.Join(dir, "multisigned.json")
+	s.Require().NoError(os.WriteFile(signedFile, out.Bytes(), 0o600))
+
+	out, err = s.execRootCmd(val, "tx", "validate-signatures", signedFile,
+		fmt.Sprintf("--%s=%s", flags.FlagChainID, s.cfg.ChainID),
+	)
+	s.Require().NoError(err, out.String())
+	s.Require().Contains(out.String(), fmt.Sprintf("%s\t\t\t[OK]", multisigAddr))
+
+	s.broadcastTx(val, signedFile)
+	s.requireBalances(val, receiver, amount)
+}
+
+// generateSendTx writes an unsigned bank send from from to to, as
+// --generate-only prints it, and returns the file name.
+func (s *IntegrationTestSuite) generateSendTx(val *network.Validator, from, to sdk.AccAddress, amount sdk.Coins, dir string) string {
+	out, err := s.execRootCmd(val,
+		"tx", "bank", "send", from.String(), to.String(), amount.String(),
+		fmt.Sprintf("--%s=true", flags.FlagGenerateOnly),
+		fmt.Sprintf("--%s=%s", flags.FlagChainID, s.cfg.ChainID),
+		fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10))).String()),
+	)
+	s.Require().NoError(err)
+
+	file := filepath.Join(dir, "unsigned.json")
+	s.Require().NoError(os.WriteFile(file, out.Bytes(), 0o600))
+
+	return file
+}
+
+// broadcastTx broadcasts the signed tx in file and requires it to succeed.
+func (s *IntegrationTestSuite) broadcastTx(val *network.Validator, file string) {
+	out, err := s.execRootCmd(val, "tx", "broadcast", file,
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=json", tmcli.OutputFlag),
+	)
+	s.Require().NoError(err)
+
+	var txRes sdk.TxResponse
+	s.Require().NoError(val.ClientCtx.Codec.UnmarshalJSON(out.Bytes(), &txRes), out.String())
+	s.Require().Equal(uint32(0), txRes.Code, txRes.RawLog)
+}
+
+func (s *IntegrationTestSuite) requireBalances(val *network.Validator, addr sdk.AccAddress, expected sdk.Coins) {
+	out, err := s.execRootCmd(val,
+		"query", "bank", "balances", addr.String(),
+		fmt.Sprintf("--%s=json", tmcli.OutputFlag),
+	)
+	s.Require().NoError(err)
+
+	var balances banktypes.QueryAllBalancesResponse
+	s.Require().NoError(val.ClientCtx.Codec.UnmarshalJSON(out.Bytes(), &balances), out.String())
+	s.Require().Equal(expected, balances.Balances)
+}
+
+// keyringFlags points a command at the test keyring of val.
+func (s *IntegrationTestSuite) keyringFlags(val *network.Validator) []string {
+	return []string{
+		fmt.Sprintf("--%s=%s", flags.FlagChainID, s.cfg.ChainID),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringBackend, keyring.BackendTest),
+		fmt.Sprintf("--%s=%s", flags.FlagKeyringDir, val.ClientCtx.KeyringDir),
+	}
+}
+
+// execRootCmd executes args with a fresh jeongseupd root command, pointed at
+// the home and RPC address of val, and returns what the command printed.
+func (s *IntegrationTestSuite) execRootCmd(val *network.Validator, args ...string) (*bytes.Buffer, error) {
+	rootCmd, _ := jsccmd.NewRootCmd()
+
+	// root command 가 만든 client context 는 stdout 에 쓰므로 output 만 바꿔준다.
+	// sign, validate-signatures 처럼 cobra 로 출력하는 command 도 같은 buffer 에 쓴다.
+	out := new(bytes.Buffer)
+	rootCmd.SetOut(out)
+	preRun := rootCmd.PersistentPreRunE
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := preRun(cmd, args); err != nil {
+			return err
+		}
+
+		return client.SetCmdClientContext(cmd, client.GetClientContextFromCmd(cmd).WithOutput(out))
+	}
+
+	rootCmd.SetArgs(append(args,
+		fmt.Sprintf("--%s=%s", flags.FlagHome, val.ClientCtx.HomeDir),
+		fmt.Sprintf("--%s=%s", flags.FlagNode, val.RPCAddress),
+	))
+
+	return out, svrcmd.Execute(rootCmd, val.ClientCtx.HomeDir)
+}
+
+func TestIntegrationTestSuite(t *testing.T) {
+	suite.Run(t, new(IntegrationTestSuite))
+}